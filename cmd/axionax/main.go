@@ -2,9 +2,16 @@ package main
 
 import (
 	"fmt"
+	"math/big"
 	"os"
+	"time"
 
 	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/da"
+	"github.com/axionaxprotocol/axionax-core/pkg/popc"
+	"github.com/axionaxprotocol/axionax-core/pkg/ppc"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 )
 
@@ -47,6 +54,10 @@ Complete documentation is available at https://docs.axionax.org`,
 		validatorCmd(),
 		workerCmd(),
 		configCmd(),
+		popcCmd(),
+		jobCmd(),
+		ppcCmd(),
+		daCmd(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -306,3 +317,377 @@ func configCmd() *cobra.Command {
 
 	return cmd
 }
+
+func popcCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "popc",
+		Short: "Proof-of-Probabilistic-Checking tools",
+	}
+
+	var (
+		outputSize int
+		fraction   float64
+		sampleSize int
+		confidence float64
+	)
+
+	paramsCmd := &cobra.Command{
+		Use:   "params",
+		Short: "Compute PoPC detection probability and minimum sample size",
+		Long: `Compute the probability that a PoPC sample detects a partially corrupted
+output, and the minimum sample size needed to reach a target confidence.
+An output size of 0 models an unbounded output.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Read without validating, so the command can diagnose a
+			// configuration that LoadConfig rejects
+			cfg, err := config.ReadConfig(cfgFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if !cmd.Flags().Changed("sample-size") {
+				sampleSize = cfg.PoPC.SampleSize
+			}
+			if !cmd.Flags().Changed("fraction") {
+				fraction = cfg.PoPC.DetectableFraction
+			}
+			if !cmd.Flags().Changed("confidence") {
+				confidence = cfg.PoPC.MinConfidence
+			}
+
+			prob, err := popc.DetectionProbability(outputSize, fraction, sampleSize)
+			if err != nil {
+				return err
+			}
+			minSize, err := popc.MinSampleSize(outputSize, fraction, confidence)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("📐 PoPC Parameters:\n")
+			if outputSize > 0 {
+				fmt.Printf("  Output Size: %d\n", outputSize)
+			} else {
+				fmt.Printf("  Output Size: unbounded\n")
+			}
+			fmt.Printf("  Corrupted Fraction: %g\n", fraction)
+			fmt.Printf("  Sample Size: %d\n", sampleSize)
+			fmt.Printf("  Detection Probability: %.6f\n", prob)
+			fmt.Printf("  Min Sample Size for %g: %d\n", confidence, minSize)
+			if prob >= confidence {
+				fmt.Println("✅ Sample size meets target confidence")
+			} else {
+				fmt.Println("⚠️  Sample size is below target confidence")
+			}
+			if err := cfg.PoPC.Validate(); err != nil {
+				fmt.Printf("⚠️  Configured PoPC settings are invalid: %v\n", err)
+			}
+			return nil
+		},
+	}
+
+	paramsCmd.Flags().IntVar(&outputSize, "output-size", 0, "number of output elements (0 for unbounded)")
+	paramsCmd.Flags().Float64Var(&fraction, "fraction", 0, "assumed fraction of corrupted results (default from config)")
+	paramsCmd.Flags().IntVar(&sampleSize, "sample-size", 0, "PoPC sample size (default from config)")
+	paramsCmd.Flags().Float64Var(&confidence, "confidence", 0, "target detection probability (default from config)")
+
+	cmd.AddCommand(paramsCmd)
+
+	return cmd
+}
+
+func jobCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "job",
+		Short: "Compute job tools",
+	}
+
+	var (
+		specs    types.JobSpecs
+		sla      types.SLA
+		duration time.Duration
+		price    float64
+	)
+
+	quoteCmd := &cobra.Command{
+		Use:   "quote",
+		Short: "Quote the expected price of a job before submitting it",
+		Long: `Quote the expected price of a job from its resource class's posted price
+(AXX per hour), SLA premiums and expected duration. Without --price no
+posted price is known, so the quote is a lower bound computed from the
+configured floor price p_min.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig(cfgFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			posted := cmd.Flags().Changed("price")
+			if !posted {
+				price = cfg.PPC.MinPrice
+			}
+
+			book, err := ppc.NewPriceBook(cfg.PPC, price)
+			if err != nil {
+				return err
+			}
+			q, err := book.Quote(specs, sla, duration)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("💰 Job Quote:\n")
+			fmt.Printf("  Resource Class: %s\n", q.Class)
+			if posted {
+				fmt.Printf("  Posted Price: %g AXX/hour\n", q.UnitPrice)
+			} else {
+				fmt.Printf("  Floor Price (p_min): %g AXX/hour\n", q.UnitPrice)
+			}
+			fmt.Printf("  Duration: %s\n", q.Duration)
+			fmt.Printf("  Latency Multiplier: %.4f\n", q.LatencyMultiplier)
+			fmt.Printf("  Uptime Multiplier: %.4f\n", q.UptimeMultiplier)
+			fmt.Printf("  Total: %s AXX (%s base units)\n", formatAXX(q.Total), q.Total)
+			if !posted {
+				fmt.Printf("⚠️  No posted price given: the total is a floor, pass --price for the current posted price\n")
+			}
+			return nil
+		},
+	}
+
+	quoteCmd.Flags().StringVar(&specs.GPU, "gpu", "", "GPU model (empty for CPU-only jobs)")
+	quoteCmd.Flags().IntVar(&specs.VRAM, "vram", 0, "required VRAM in GB")
+	quoteCmd.Flags().StringVar(&specs.Region, "region", "", "preferred region")
+	quoteCmd.Flags().DurationVar(&sla.MaxLatency, "max-latency", 0, "SLA max latency (0 for no limit)")
+	quoteCmd.Flags().Float64Var(&sla.RequiredUptime, "uptime", 0, "SLA required uptime, 0.0 to 1.0")
+	quoteCmd.Flags().DurationVar(&duration, "duration", time.Hour, "expected job duration")
+	quoteCmd.Flags().Float64Var(&price, "price", 0, "posted price in AXX/hour (default: quote a floor from p_min)")
+
+	cmd.AddCommand(quoteCmd)
+
+	return cmd
+}
+
+func ppcCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ppc",
+		Short: "Posted Price Controller tools",
+	}
+
+	var (
+		tracePath        string
+		outPath          string
+		capacity         int
+		price            float64
+		alpha            float64
+		beta             float64
+		jobs             int
+		meanInterarrival float64
+		meanDuration     float64
+		maxPrice         float64
+		seed             string
+		maxIntervals     int
+	)
+
+	simulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Replay a demand trace through the price controller",
+		Long: `Replay a demand trace through the Posted Price Controller and report the
+price trajectory, utilization and queue time, so controller parameters can
+be evaluated offline.
+
+The trace is a CSV file with columns arrival,duration[,max_price], times in
+seconds from the start of the trace. Jobs whose max_price is below the
+posted price on arrival are dropped. Without --trace a synthetic trace is
+generated from --jobs, --mean-interarrival, --mean-duration and --seed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig(cfgFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if cmd.Flags().Changed("alpha") {
+				cfg.PPC.Alpha = alpha
+			}
+			if cmd.Flags().Changed("beta") {
+				cfg.PPC.Beta = beta
+			}
+			posted := cmd.Flags().Changed("price")
+			if !posted {
+				price = cfg.PPC.MinPrice
+			}
+
+			var trace []ppc.TraceJob
+			if tracePath != "" {
+				f, err := os.Open(tracePath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				if trace, err = ppc.ReadTrace(f); err != nil {
+					return err
+				}
+			} else {
+				trace = ppc.GenerateTrace(common.HexToHash(seed), jobs, meanInterarrival, meanDuration, maxPrice)
+			}
+
+			res, err := ppc.Simulate(cfg.PPC, price, capacity, trace, time.Unix(0, 0), maxIntervals)
+			if err != nil {
+				return err
+			}
+
+			if outPath != "" {
+				f, err := os.Create(outPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				history := ppc.NewPriceHistory(0)
+				for _, p := range res.Points {
+					history.Record(p)
+				}
+				if err := history.WriteCSV(f); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("%10s %12s %8s %10s\n", "elapsed", "price", "util", "queue(s)")
+				for _, p := range res.Points {
+					fmt.Printf("%10s %12.6f %8.4f %10.2f\n", p.At.Sub(time.Unix(0, 0)), p.Price, p.Utilization, p.QueueTime)
+				}
+			}
+
+			fmt.Printf("📈 PPC Simulation (α=%g, β=%g):\n", cfg.PPC.Alpha, cfg.PPC.Beta)
+			fmt.Printf("  Jobs: %d (completed %d, dropped %d)\n", len(trace), res.Completed, res.Dropped)
+			fmt.Printf("  Intervals: %d of %s\n", len(res.Points), cfg.PPC.AdjustmentInterval)
+			fmt.Printf("  Mean Price: %.6f AXX/hour\n", res.MeanPrice)
+			fmt.Printf("  Final Price: %.6f AXX/hour\n", res.FinalPrice)
+			fmt.Printf("  Mean Utilization: %.4f (target %g)\n", res.MeanUtilization, cfg.PPC.TargetUtilization)
+			fmt.Printf("  Mean Queue Time: %.2fs (target %gs)\n", res.MeanQueueTime, cfg.PPC.TargetQueueTime)
+			if outPath != "" {
+				fmt.Printf("✅ Price trajectory written to %s\n", outPath)
+			}
+			return nil
+		},
+	}
+
+	simulateCmd.Flags().StringVar(&tracePath, "trace", "", "demand trace CSV (arrival,duration[,max_price])")
+	simulateCmd.Flags().StringVar(&outPath, "out", "", "write the price trajectory to this CSV file instead of stdout")
+	simulateCmd.Flags().IntVar(&capacity, "capacity", 16, "number of concurrent job slots")
+	simulateCmd.Flags().Float64Var(&price, "price", 0, "starting price in AXX/hour (default p_min from config)")
+	simulateCmd.Flags().Float64Var(&alpha, "alpha", 0, "utilization gain α (default from config)")
+	simulateCmd.Flags().Float64Var(&beta, "beta", 0, "queue gain β (default from config)")
+	simulateCmd.Flags().IntVar(&jobs, "jobs", 1000, "synthetic trace: number of jobs")
+	simulateCmd.Flags().Float64Var(&meanInterarrival, "mean-interarrival", 10, "synthetic trace: mean seconds between arrivals")
+	simulateCmd.Flags().Float64Var(&meanDuration, "mean-duration", 120, "synthetic trace: mean job duration in seconds")
+	simulateCmd.Flags().Float64Var(&maxPrice, "max-price", 0, "synthetic trace: upper bound of client max prices (0 for unlimited)")
+	simulateCmd.Flags().StringVar(&seed, "seed", "0x1", "synthetic trace: seed")
+	simulateCmd.Flags().IntVar(&maxIntervals, "max-intervals", ppc.DefaultMaxIntervals, "stop with an error after this many adjustment intervals")
+
+	cmd.AddCommand(simulateCmd)
+
+	return cmd
+}
+
+func daCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "da",
+		Short: "Data availability storage management",
+	}
+
+	// openRetention opens the configured DA store and its retention manager
+	openRetention := func() (*da.Retention, error) {
+		cfg, err := config.LoadConfig(cfgFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		store, err := da.NewStore(cfg.DA)
+		if err != nil {
+			return nil, err
+		}
+		return da.NewRetention(store, cfg.DA, cfg.PoPC)
+	}
+
+	var dryRun bool
+	gcCmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete job outputs past their retention period",
+		Long: `Delete stored job outputs whose retention period has ended. Outputs are kept
+for the availability window plus the fraud window after they are stored,
+and for as long as they are pinned by a dispute. With --dry-run nothing is
+deleted and the report lists what would be.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := openRetention()
+			if err != nil {
+				return err
+			}
+			res, err := r.GC(time.Now(), dryRun)
+			if err != nil {
+				return err
+			}
+
+			verb := "Removed"
+			if res.DryRun {
+				verb = "Would remove"
+			}
+			fmt.Printf("🗑️  DA Garbage Collection (retention %s):\n", r.Period())
+			for _, e := range res.Removed {
+				fmt.Printf("  %s %s (%d bytes, expired %s)\n", verb, e.JobID, e.Size, e.ExpiresAt.Format(time.RFC3339))
+			}
+			for _, e := range res.Pinned {
+				fmt.Printf("  Kept %s (%d bytes, pinned)\n", e.JobID, e.Size)
+			}
+			fmt.Printf("  %s: %d jobs, %d bytes\n", verb, len(res.Removed), res.Freed)
+			fmt.Printf("  Retained: %d jobs, %d pinned\n", res.Retained, len(res.Pinned))
+			if res.Quota > 0 {
+				fmt.Printf("  Used: %d of %d bytes\n", res.Used, res.Quota)
+			} else {
+				fmt.Printf("  Used: %d bytes (no quota)\n", res.Used)
+			}
+			if res.OverQuota {
+				fmt.Println("⚠️  Over disk quota: new outputs are refused until data expires")
+			}
+			return nil
+		},
+	}
+	gcCmd.Flags().BoolVar(&dryRun, "dry-run", false, "report what would be deleted without deleting it")
+
+	pinCmd := &cobra.Command{
+		Use:   "pin [job-id]",
+		Short: "Keep a job's output past its retention period",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := openRetention()
+			if err != nil {
+				return err
+			}
+			if err := r.Pin(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("📌 Pinned %s\n", args[0])
+			return nil
+		},
+	}
+
+	unpinCmd := &cobra.Command{
+		Use:   "unpin [job-id]",
+		Short: "Let a pinned job's output expire",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := openRetention()
+			if err != nil {
+				return err
+			}
+			if err := r.Unpin(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("✅ Unpinned %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.AddCommand(gcCmd, pinCmd, unpinCmd)
+
+	return cmd
+}
+
+// formatAXX renders an amount of base units as AXX
+func formatAXX(units *big.Int) string {
+	unit := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(ppc.Decimals), nil))
+	return new(big.Float).Quo(new(big.Float).SetInt(units), unit).Text('f', ppc.QuotePrecision)
+}
@@ -0,0 +1,114 @@
+package asr
+
+import (
+	"strings"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OperatorSet records the nodes already involved in a job, by address and
+// by the ASN and organization operating them, so selection can avoid giving
+// the same operator a second role on the job
+type OperatorSet struct {
+	addresses map[common.Address]struct{}
+	asns      map[string]struct{}
+	orgs      map[string]struct{}
+}
+
+// NewOperatorSet creates an empty operator set
+func NewOperatorSet() *OperatorSet {
+	return &OperatorSet{
+		addresses: make(map[common.Address]struct{}),
+		asns:      make(map[string]struct{}),
+		orgs:      make(map[string]struct{}),
+	}
+}
+
+// AddWorker adds a worker's address, ASN and organization
+func (s *OperatorSet) AddWorker(w *types.Worker) {
+	s.addresses[w.Address] = struct{}{}
+	if asn := operatorKey(w.Specs.ASN); asn != "" {
+		s.asns[asn] = struct{}{}
+	}
+	if org := operatorKey(w.Specs.Organization); org != "" {
+		s.orgs[org] = struct{}{}
+	}
+}
+
+// AddAddress adds a bare address, such as a validator voting on the job
+func (s *OperatorSet) AddAddress(addr common.Address) {
+	s.addresses[addr] = struct{}{}
+}
+
+// Conflicts reports whether w may share an operator with the set. Once the
+// set holds a worker, a candidate with no declared ASN or organization
+// conflicts too, since its independence cannot be shown.
+func (s *OperatorSet) Conflicts(w *types.Worker) bool {
+	if _, ok := s.addresses[w.Address]; ok {
+		return true
+	}
+	if len(s.asns) == 0 && len(s.orgs) == 0 {
+		return false
+	}
+
+	asn, org := operatorKey(w.Specs.ASN), operatorKey(w.Specs.Organization)
+	if asn == "" || org == "" {
+		return true
+	}
+	_, sameASN := s.asns[asn]
+	_, sameOrg := s.orgs[org]
+	return sameASN || sameOrg
+}
+
+// without returns the workers that do not conflict with the set
+func (s *OperatorSet) without(workers []*types.Worker) []*types.Worker {
+	kept := make([]*types.Worker, 0, len(workers))
+	for _, w := range workers {
+		if !s.Conflicts(w) {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// diversify walks a ranking best first and keeps each candidate whose ASN
+// and organization hold fewer than maxPer kept candidates so far. An
+// undeclared ASN or organization is not counted, so workers that declare
+// neither are never capped. The result stays in rank order.
+func diversify(ranked []Candidate, maxPer int) []Candidate {
+	asns := make(map[string]int)
+	orgs := make(map[string]int)
+	kept := make([]Candidate, 0, len(ranked))
+	for _, c := range ranked {
+		asn := operatorKey(c.Worker.Specs.ASN)
+		org := operatorKey(c.Worker.Specs.Organization)
+		if (asn != "" && asns[asn] >= maxPer) || (org != "" && orgs[org] >= maxPer) {
+			continue
+		}
+		if asn != "" {
+			asns[asn]++
+		}
+		if org != "" {
+			orgs[org]++
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// differentRegion returns the workers outside the given region
+func differentRegion(workers []*types.Worker, region string) []*types.Worker {
+	kept := make([]*types.Worker, 0, len(workers))
+	for _, w := range workers {
+		if w.Specs.Region != "" && !strings.EqualFold(w.Specs.Region, region) {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// operatorKey normalizes an ASN or organization for comparison
+func operatorKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,255 @@
+package asr
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSet_Conflicts(t *testing.T) {
+	primary := testWorker(0, 0.9)
+	set := NewOperatorSet()
+	set.AddWorker(primary)
+
+	sameASN := testWorker(1, 0.9)
+	sameASN.Specs.ASN = "as1000"
+	sameOrg := testWorker(2, 0.9)
+	sameOrg.Specs.Organization = " ORG-0 "
+	unknown := testWorker(3, 0.9)
+	unknown.Specs.ASN = ""
+
+	assert.True(t, set.Conflicts(primary))
+	assert.True(t, set.Conflicts(sameASN))
+	assert.True(t, set.Conflicts(sameOrg))
+	assert.True(t, set.Conflicts(unknown))
+	assert.False(t, set.Conflicts(testWorker(4, 0.9)))
+}
+
+func TestOperatorSet_AddressOnly(t *testing.T) {
+	validator := testWorker(0, 0.9)
+	set := NewOperatorSet()
+	set.AddAddress(validator.Address)
+
+	unknown := testWorker(1, 0.9)
+	unknown.Specs.ASN = ""
+
+	assert.True(t, set.Conflicts(validator))
+	assert.False(t, set.Conflicts(unknown))
+}
+
+// sharedOperatorWorkers returns n workers on one ASN that outrank m
+// independent workers
+func sharedOperatorWorkers(n, m int) []*types.Worker {
+	workers := make([]*types.Worker, 0, n+m)
+	for i := 0; i < n; i++ {
+		w := testWorker(i, 0.99)
+		w.Specs.ASN = "AS64500"
+		workers = append(workers, w)
+	}
+	for i := n; i < n+m; i++ {
+		workers = append(workers, testWorker(i, 0.6))
+	}
+	return workers
+}
+
+func TestRouter_TopK_CapsSharedOperator(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 6
+	cfg.MaxPerOperator = 2
+	r := NewRouter(cfg)
+
+	top := r.TopK(sharedOperatorWorkers(5, 5), types.JobSpecs{})
+	require.Len(t, top, 6)
+
+	shared := 0
+	for _, c := range top {
+		if c.Worker.Specs.ASN == "AS64500" {
+			shared++
+		}
+	}
+	assert.Equal(t, 2, shared)
+}
+
+func TestRouter_TopK_UndeclaredOperatorsAreNotCapped(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 6
+	cfg.MaxPerOperator = 2
+	r := NewRouter(cfg)
+
+	// No organizations declared: only the ASNs count, and each is distinct
+	workers := make([]*types.Worker, 0, 10)
+	for i := 0; i < 10; i++ {
+		w := testWorker(i, 0.9)
+		w.Specs.Organization = ""
+		workers = append(workers, w)
+	}
+	// Neither declared: nothing to cap on
+	for i := 10; i < 14; i++ {
+		w := testWorker(i, 0.95)
+		w.Specs.ASN, w.Specs.Organization = "", ""
+		workers = append(workers, w)
+	}
+
+	top := r.TopK(workers, types.JobSpecs{})
+	require.Len(t, top, 6)
+	for _, c := range top[:4] {
+		assert.Empty(t, c.Worker.Specs.ASN)
+	}
+
+	// Exploration can still reach every worker outside the top K
+	_, rest := r.splitTopK(r.Rank(workers, types.JobSpecs{}))
+	assert.Len(t, rest, 8)
+}
+
+func TestRouter_TopK_NoCapWhenDisabled(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 6
+	cfg.MaxPerOperator = 2
+	cfg.AntiCollusionEnabled = false
+	r := NewRouter(cfg)
+
+	top := r.TopK(sharedOperatorWorkers(5, 5), types.JobSpecs{})
+	require.Len(t, top, 6)
+	for _, c := range top[:5] {
+		assert.Equal(t, "AS64500", c.Worker.Specs.ASN)
+	}
+}
+
+func TestRouter_SelectAvoiding(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	workers := []*types.Worker{testWorker(0, 0.9), testWorker(1, 0.9)}
+
+	avoid := NewOperatorSet()
+	avoid.AddWorker(workers[0])
+	for i := 0; i < 20; i++ {
+		w, err := r.SelectAvoiding(testJob(fmt.Sprintf("job-%d", i)), workers, common.Hash{}, avoid)
+		require.NoError(t, err)
+		assert.Equal(t, workers[1].Address, w.Address)
+	}
+
+	avoid.AddWorker(workers[1])
+	_, err := r.SelectAvoiding(testJob("job"), workers, common.Hash{}, avoid)
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
+
+func TestRouter_SelectReplica(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	job := testJob("job-1")
+	job.Specs.Region = ""
+
+	primary := testWorker(0, 0.9)
+	sameOrg := testWorker(1, 0.99)
+	sameOrg.Specs.Organization = primary.Specs.Organization
+	validator := testWorker(2, 0.99)
+	sameRegion := testWorker(3, 0.9)
+	otherRegion := testWorker(4, 0.5)
+	otherRegion.Specs.Region = "eu-central"
+
+	workers := []*types.Worker{primary, sameOrg, validator, sameRegion, otherRegion}
+	validators := []common.Address{validator.Address}
+
+	// The only independent worker outside the primary's region always wins
+	for i := 0; i < 20; i++ {
+		w, err := r.SelectReplica(job, primary, validators, workers, common.BigToHash(big.NewInt(int64(i))))
+		require.NoError(t, err)
+		assert.Equal(t, otherRegion.Address, w.Address)
+	}
+
+	// Without it the replica falls back to the primary's region
+	w, err := r.SelectReplica(job, primary, validators, workers[:4], common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t, sameRegion.Address, w.Address)
+
+	_, err = r.SelectReplica(job, primary, validators, workers[:3], common.Hash{})
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
+
+func TestRouter_SelectReplica_AntiCollusionDisabled(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.AntiCollusionEnabled = false
+	r := NewRouter(cfg)
+	job := testJob("job-1")
+
+	primary := testWorker(0, 0.9)
+	sameOrg := testWorker(1, 0.9)
+	sameOrg.Specs.Organization = primary.Specs.Organization
+	sameRegion := testWorker(2, 0.9)
+	otherRegion := testWorker(3, 0.1)
+	otherRegion.Specs.Region = "eu-central"
+
+	// Replicas stay operator-independent, but no other region is preferred
+	_, err := r.SelectReplica(job, primary, nil, []*types.Worker{primary, sameOrg}, common.Hash{})
+	assert.ErrorIs(t, err, ErrNoCandidates)
+
+	picked := make(map[common.Address]bool)
+	for i := 0; i < 50; i++ {
+		w, err := r.SelectReplica(job, primary, nil, []*types.Worker{primary, sameOrg, sameRegion, otherRegion}, common.BigToHash(big.NewInt(int64(i))))
+		require.NoError(t, err)
+		picked[w.Address] = true
+	}
+	assert.True(t, picked[sameRegion.Address])
+	assert.False(t, picked[sameOrg.Address])
+}
+
+func TestRouter_SelectReplica_Independence(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	job := testJob("job-replica")
+	primary := testWorker(0, 0.9)
+
+	sameASN := testWorker(1, 0.99)
+	sameASN.Specs.ASN = " as1000 "
+	sameOrg := testWorker(2, 0.99)
+	sameOrg.Specs.Organization = "ORG-0"
+	unknown := testWorker(3, 0.99)
+	unknown.Specs.ASN = ""
+	inactive := testWorker(4, 0.99)
+	inactive.Status = types.WorkerStatusInactive
+	independent := testWorker(5, 0.5)
+
+	workers := []*types.Worker{primary, sameASN, sameOrg, unknown, inactive, independent}
+	for i := 0; i < 20; i++ {
+		w, err := r.SelectReplica(job, primary, nil, workers, common.BigToHash(big.NewInt(int64(i))))
+		require.NoError(t, err)
+		assert.Equal(t, independent.Address, w.Address)
+	}
+
+	_, err := r.SelectReplica(job, primary, nil, workers[:5], common.Hash{})
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
+
+func TestRouter_SelectReplica_OrderIndependent(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	job := testJob("job-order")
+	primary := testWorker(0, 0.9)
+	a, b, c := testWorker(1, 0.8), testWorker(2, 0.8), testWorker(3, 0.8)
+	seed := common.HexToHash("0x43")
+
+	first, err := r.SelectReplica(job, primary, nil, []*types.Worker{a, b, c}, seed)
+	require.NoError(t, err)
+	second, err := r.SelectReplica(job, primary, nil, []*types.Worker{c, a, b}, seed)
+	require.NoError(t, err)
+	assert.Equal(t, first.Address, second.Address)
+}
+
+func TestRouter_ExplorationSkipsCappedOperators(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 3
+	cfg.MaxPerOperator = 2
+	cfg.ExplorationRate = 1
+	r := NewRouter(cfg)
+
+	// Five workers on one ASN outrank five independent ones: two of the
+	// shared workers make the top K, the other three are over the cap
+	workers := sharedOperatorWorkers(5, 5)
+	for i := 0; i < 100; i++ {
+		w, err := r.Select(testJob(fmt.Sprintf("job-%d", i)), workers, common.Hash{})
+		require.NoError(t, err)
+		assert.NotEqual(t, "AS64500", w.Specs.ASN, "exploration must not reach capped workers")
+	}
+}
@@ -0,0 +1,190 @@
+package asr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RejectionCode identifies why a worker cannot run a job
+type RejectionCode string
+
+const (
+	RejectInactive  RejectionCode = "inactive"
+	RejectGPU       RejectionCode = "gpu"
+	RejectVRAM      RejectionCode = "vram"
+	RejectFramework RejectionCode = "framework"
+	RejectRegion    RejectionCode = "region"
+	RejectTags      RejectionCode = "tags"
+)
+
+// Rejection is one unmet hard requirement
+type Rejection struct {
+	Code RejectionCode `json:"code"`
+	Want string        `json:"want"`
+	Have string        `json:"have"`
+}
+
+func (r Rejection) String() string {
+	return fmt.Sprintf("%s: want %s, have %s", r.Code, r.Want, r.Have)
+}
+
+// Eligibility is the result of matching a worker against a job spec
+type Eligibility struct {
+	Worker     common.Address `json:"worker"`
+	Rejections []Rejection    `json:"rejections,omitempty"`
+}
+
+// Eligible reports whether the worker meets every hard requirement
+func (e Eligibility) Eligible() bool {
+	return len(e.Rejections) == 0
+}
+
+// NoEligibleError is returned when every worker was filtered out of a job
+type NoEligibleError struct {
+	JobID    string
+	Rejected []Eligibility
+}
+
+func (e *NoEligibleError) Error() string {
+	counts := SummarizeRejections(e.Rejected)
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, string(code))
+	}
+	sort.Strings(codes)
+
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%s=%d", code, counts[RejectionCode(code)])
+	}
+	return fmt.Sprintf("asr: no eligible workers for job %s (%d rejected: %s)", e.JobID, len(e.Rejected), strings.Join(parts, ", "))
+}
+
+func (e *NoEligibleError) Unwrap() error {
+	return ErrNoCandidates
+}
+
+// CheckEligibility matches a worker's hardware, region and advertised
+// frameworks and tags against a job's hard requirements
+func CheckEligibility(w *types.Worker, spec types.JobSpecs) Eligibility {
+	result := Eligibility{Worker: w.Address}
+	reject := func(code RejectionCode, want, have string) {
+		result.Rejections = append(result.Rejections, Rejection{Code: code, Want: want, Have: have})
+	}
+
+	if w.Status != types.WorkerStatusActive {
+		reject(RejectInactive, string(types.WorkerStatusActive), string(w.Status))
+	}
+
+	if spec.GPU != "" || spec.VRAM > 0 {
+		checkGPUs(w.Specs.GPUs, spec, reject)
+	}
+
+	if spec.Framework != "" && !containsFold(w.Specs.Frameworks, spec.Framework) {
+		reject(RejectFramework, spec.Framework, listOrNone(w.Specs.Frameworks))
+	}
+
+	if spec.Region != "" && !strings.EqualFold(spec.Region, w.Specs.Region) {
+		reject(RejectRegion, spec.Region, orNone(w.Specs.Region))
+	}
+
+	var missing []string
+	for _, tag := range spec.Tags {
+		if !containsFold(w.Specs.Tags, tag) {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		reject(RejectTags, strings.Join(missing, ","), listOrNone(w.Specs.Tags))
+	}
+
+	return result
+}
+
+// FilterEligible splits workers into those that can run the job and the
+// rejections of those that cannot
+func FilterEligible(workers []*types.Worker, spec types.JobSpecs) ([]*types.Worker, []Eligibility) {
+	var eligible []*types.Worker
+	var rejected []Eligibility
+	for _, w := range workers {
+		if e := CheckEligibility(w, spec); e.Eligible() {
+			eligible = append(eligible, w)
+		} else {
+			rejected = append(rejected, e)
+		}
+	}
+	return eligible, rejected
+}
+
+// SummarizeRejections counts how many workers failed each requirement
+func SummarizeRejections(rejected []Eligibility) map[RejectionCode]int {
+	counts := make(map[RejectionCode]int)
+	for _, e := range rejected {
+		for _, r := range e.Rejections {
+			counts[r.Code]++
+		}
+	}
+	return counts
+}
+
+// checkGPUs requires at least one GPU of the requested model with enough
+// per-GPU VRAM. A model mismatch and a VRAM shortfall are reported separately.
+func checkGPUs(gpus []types.GPUSpec, spec types.JobSpecs, reject func(RejectionCode, string, string)) {
+	modelMatch, bestVRAM := false, 0
+	for _, gpu := range gpus {
+		if gpu.Count <= 0 {
+			continue
+		}
+		if spec.GPU != "" && types.NormalizeGPU(gpu.Model) != types.NormalizeGPU(spec.GPU) {
+			continue
+		}
+		modelMatch = true
+		if gpu.VRAM > bestVRAM {
+			bestVRAM = gpu.VRAM
+		}
+	}
+
+	if !modelMatch {
+		have := make([]string, 0, len(gpus))
+		for _, gpu := range gpus {
+			have = append(have, fmt.Sprintf("%dx %s", gpu.Count, gpu.Model))
+		}
+		want := spec.GPU
+		if want == "" {
+			want = "any GPU"
+		}
+		reject(RejectGPU, want, listOrNone(have))
+		return
+	}
+
+	if bestVRAM < spec.VRAM {
+		reject(RejectVRAM, fmt.Sprintf("%d GB", spec.VRAM), fmt.Sprintf("%d GB", bestVRAM))
+	}
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func listOrNone(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ",")
+}
+
+func orNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
@@ -0,0 +1,102 @@
+package asr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eligibleWorker() *types.Worker {
+	w := testWorker(0, 0.9)
+	w.Specs.GPUs = []types.GPUSpec{
+		{Model: "NVIDIA RTX 4090", VRAM: 24, Count: 2},
+		{Model: "NVIDIA A100", VRAM: 80, Count: 1},
+	}
+	w.Specs.Frameworks = []string{"PyTorch", "JAX"}
+	w.Specs.Tags = []string{"ml", "inference"}
+	return w
+}
+
+func codes(e Eligibility) []RejectionCode {
+	var out []RejectionCode
+	for _, r := range e.Rejections {
+		out = append(out, r.Code)
+	}
+	return out
+}
+
+func TestCheckEligibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     types.JobSpecs
+		modify   func(*types.Worker)
+		expected []RejectionCode
+	}{
+		{"No requirements", types.JobSpecs{}, nil, nil},
+		{"Full match", types.JobSpecs{GPU: "NVIDIA A100", VRAM: 80, Framework: "pytorch", Region: "US-WEST", Tags: []string{"ml"}}, nil, nil},
+		{"Model without vendor prefix", types.JobSpecs{GPU: "rtx 4090", VRAM: 24}, nil, nil},
+		{"VRAM only", types.JobSpecs{VRAM: 40}, nil, nil},
+		{"Wrong GPU model", types.JobSpecs{GPU: "NVIDIA H100"}, nil, []RejectionCode{RejectGPU}},
+		{"Per-GPU VRAM too small", types.JobSpecs{GPU: "NVIDIA RTX 4090", VRAM: 48}, nil, []RejectionCode{RejectVRAM}},
+		{"No GPUs", types.JobSpecs{VRAM: 8}, func(w *types.Worker) { w.Specs.GPUs = nil }, []RejectionCode{RejectGPU}},
+		{"Zero count GPU", types.JobSpecs{GPU: "NVIDIA A100"}, func(w *types.Worker) { w.Specs.GPUs[1].Count = 0 }, []RejectionCode{RejectGPU}},
+		{"Framework", types.JobSpecs{Framework: "TensorFlow"}, nil, []RejectionCode{RejectFramework}},
+		{"Region", types.JobSpecs{Region: "eu-central"}, nil, []RejectionCode{RejectRegion}},
+		{"Tags", types.JobSpecs{Tags: []string{"ml", "training"}}, nil, []RejectionCode{RejectTags}},
+		{"Inactive", types.JobSpecs{}, func(w *types.Worker) { w.Status = types.WorkerStatusSuspended }, []RejectionCode{RejectInactive}},
+		{"Several", types.JobSpecs{GPU: "NVIDIA H100", Region: "eu-central"}, nil, []RejectionCode{RejectGPU, RejectRegion}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := eligibleWorker()
+			if tt.modify != nil {
+				tt.modify(w)
+			}
+			e := CheckEligibility(w, tt.spec)
+			assert.Equal(t, tt.expected, codes(e))
+			assert.Equal(t, len(tt.expected) == 0, e.Eligible())
+		})
+	}
+}
+
+func TestCheckEligibility_ReasonDetail(t *testing.T) {
+	e := CheckEligibility(eligibleWorker(), types.JobSpecs{GPU: "NVIDIA RTX 4090", VRAM: 48})
+	require.Len(t, e.Rejections, 1)
+	assert.Equal(t, "48 GB", e.Rejections[0].Want)
+	assert.Equal(t, "24 GB", e.Rejections[0].Have)
+	assert.Equal(t, "vram: want 48 GB, have 24 GB", e.Rejections[0].String())
+}
+
+func TestFilterEligible(t *testing.T) {
+	ok := eligibleWorker()
+	far := eligibleWorker()
+	far.Address = common.HexToAddress("0x02")
+	far.Specs.Region = "ap-south"
+
+	eligible, rejected := FilterEligible([]*types.Worker{ok, far}, types.JobSpecs{Region: "us-west"})
+	assert.Equal(t, []*types.Worker{ok}, eligible)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, far.Address, rejected[0].Worker)
+	assert.Equal(t, map[RejectionCode]int{RejectRegion: 1}, SummarizeRejections(rejected))
+}
+
+func TestRouter_SelectExplainsPending(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	job := testJob("job-pending")
+	job.Specs = types.JobSpecs{GPU: "NVIDIA H100", VRAM: 80}
+
+	_, err := r.Select(job, []*types.Worker{eligibleWorker()}, common.HexToHash("0x60"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNoCandidates)
+
+	var ne *NoEligibleError
+	require.True(t, errors.As(err, &ne))
+	assert.Len(t, ne.Rejected, 1)
+	assert.Contains(t, err.Error(), "gpu=1")
+}
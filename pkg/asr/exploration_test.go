@@ -0,0 +1,102 @@
+package asr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_NewcomerBoost(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	spec := types.JobSpecs{}
+
+	veteran := testWorker(0, 0.8)
+	veteran.Performance.TotalJobs = 500
+	newcomer := testWorker(1, 0.8)
+	newcomer.IsNewcomer = true
+	newcomer.Performance.TotalJobs = 3
+
+	assert.InDelta(t, r.Score(veteran, spec)+0.1, r.Score(newcomer, spec), 1e-9)
+
+	// The boost stops at NewcomerJobs even before the flag is cleared
+	newcomer.Performance.TotalJobs = 20
+	assert.InDelta(t, r.Score(veteran, spec), r.Score(newcomer, spec), 1e-9)
+}
+
+func TestRouter_SelectLeavesNewcomerFlag(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+
+	w := testWorker(0, 0.8)
+	w.IsNewcomer = true
+	w.Performance.TotalJobs = 20
+	_, err := r.Select(testJob("job-refresh"), []*types.Worker{w}, common.HexToHash("0x80"))
+	require.NoError(t, err)
+	assert.True(t, w.IsNewcomer, "selection is read-only")
+}
+
+func TestRouter_Exploration(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 2
+	cfg.ExplorationRate = 0.2
+	r := NewRouter(cfg)
+
+	var workers []*types.Worker
+	for i := 0; i < 10; i++ {
+		workers = append(workers, testWorker(i, 0.5+float64(i)*0.05))
+	}
+	top := map[common.Address]bool{workers[9].Address: true, workers[8].Address: true}
+
+	outside := 0
+	for i := 0; i < 2000; i++ {
+		w, err := r.Select(testJob(fmt.Sprintf("job-%d", i)), workers, common.HexToHash("0x81"))
+		require.NoError(t, err)
+		if !top[w.Address] {
+			outside++
+		}
+	}
+
+	// ε = 20% of 2000 = 400
+	assert.InDelta(t, 400, outside, 80)
+}
+
+func TestRouter_NoExplorationWhenDisabled(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 1
+	cfg.ExplorationRate = 0
+	r := NewRouter(cfg)
+
+	best := testWorker(0, 1)
+	others := []*types.Worker{best, testWorker(1, 0.5), testWorker(2, 0.4)}
+
+	for i := 0; i < 100; i++ {
+		w, err := r.Select(testJob(fmt.Sprintf("job-%d", i)), others, common.HexToHash("0x82"))
+		require.NoError(t, err)
+		assert.Equal(t, best.Address, w.Address)
+	}
+}
+
+func TestRouter_ExplorationReplayable(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 3
+	cfg.ExplorationRate = 0.5
+	r := NewRouter(cfg)
+
+	var workers []*types.Worker
+	for i := 0; i < 12; i++ {
+		workers = append(workers, testWorker(i, 0.6))
+	}
+
+	for i := 0; i < 50; i++ {
+		job := testJob(fmt.Sprintf("job-%d", i))
+		a, err := r.Select(job, workers, common.HexToHash("0x83"))
+		require.NoError(t, err)
+		b, err := r.Select(job, workers, common.HexToHash("0x83"))
+		require.NoError(t, err)
+		assert.Equal(t, a.Address, b.Address)
+	}
+}
@@ -0,0 +1,254 @@
+package asr
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Day is the unit of ASRConfig.PerformanceWindow and PerformanceHalfLife
+const Day = 24 * time.Hour
+
+// JobOutcome is the result of one job run by a worker
+type JobOutcome struct {
+	JobID      string         `json:"job_id"`
+	Worker     common.Address `json:"worker"`
+	Success    bool           `json:"success"`
+	PoPCPassed bool           `json:"popc_passed"`
+	Latency    float64        `json:"latency"` // in seconds
+	At         time.Time      `json:"at"`
+}
+
+// AuditOutcome is the result of one DA audit request for a shard a worker
+// stores
+type AuditOutcome struct {
+	JobID  string         `json:"job_id"`
+	Worker common.Address `json:"worker"`
+	Shard  int            `json:"shard"`
+	Passed bool           `json:"passed"`
+	At     time.Time      `json:"at"`
+}
+
+// jobCounts are job counts not yet added to a worker's lifetime totals
+type jobCounts struct {
+	total, successful, failed int
+}
+
+// History keeps each worker's recent job outcomes and DA audits and
+// recomputes their PerformanceStats over a rolling window. Records older
+// than the window are ignored; with a half-life set, the rates weight
+// recent records more.
+type History struct {
+	mu           sync.Mutex
+	window       time.Duration
+	halfLife     time.Duration
+	newcomerJobs int
+	outcomes     map[common.Address][]JobOutcome
+	audits       map[common.Address][]AuditOutcome
+	pending      map[common.Address]jobCounts
+}
+
+// NewHistory creates a history using ASRConfig.PerformanceWindow,
+// PerformanceHalfLife and NewcomerJobs
+func NewHistory(cfg config.ASRConfig) *History {
+	return &History{
+		window:       time.Duration(cfg.PerformanceWindow) * Day,
+		halfLife:     time.Duration(cfg.PerformanceHalfLife * float64(Day)),
+		newcomerJobs: cfg.NewcomerJobs,
+		outcomes:     make(map[common.Address][]JobOutcome),
+		audits:       make(map[common.Address][]AuditOutcome),
+		pending:      make(map[common.Address]jobCounts),
+	}
+}
+
+// Window returns the length of the rolling window
+func (h *History) Window() time.Duration {
+	return h.window
+}
+
+// Record adds a job outcome
+func (h *History) Record(o JobOutcome) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.outcomes[o.Worker] = append(h.outcomes[o.Worker], o)
+
+	c := h.pending[o.Worker]
+	c.total++
+	if o.Success {
+		c.successful++
+	} else {
+		c.failed++
+	}
+	h.pending[o.Worker] = c
+}
+
+// RecordAudit adds a DA audit result
+func (h *History) RecordAudit(a AuditOutcome) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.audits[a.Worker] = append(h.audits[a.Worker], a)
+}
+
+// Outcomes returns a worker's outcomes inside the window ending at now
+func (h *History) Outcomes(addr common.Address, now time.Time) []JobOutcome {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var recent []JobOutcome
+	for _, o := range h.outcomes[addr] {
+		if h.inWindow(o.At, now) {
+			recent = append(recent, o)
+		}
+	}
+	return recent
+}
+
+// Stats computes a worker's performance over the window ending at now. Job
+// counts are plain counts; the PoPC pass rate and average latency are
+// decay-weighted over job outcomes and the DA reliability over audits, and
+// is left zero without any. Uptime is not tracked here and is left zero.
+// The second result is false when the worker has neither outcomes nor
+// audits in the window.
+func (h *History) Stats(addr common.Address, now time.Time) (types.PerformanceStats, bool) {
+	stats, jobs, audits := h.stats(addr, now)
+	return stats, jobs || audits
+}
+
+// Refresh updates a worker's performance rates to those over the window
+// ending at now, keeping its uptime, and adds the outcomes recorded since
+// the last refresh to its lifetime job counts. A worker with no outcomes in
+// the window keeps its previous PoPC and latency figures; one with no audits
+// keeps its DA reliability. IsNewcomer is cleared once the worker has
+// completed ASRConfig.NewcomerJobs jobs.
+func (h *History) Refresh(w *types.Worker, now time.Time) {
+	stats, jobs, audits := h.stats(w.Address, now)
+
+	h.mu.Lock()
+	c := h.pending[w.Address]
+	delete(h.pending, w.Address)
+	h.mu.Unlock()
+
+	perf := &w.Performance
+	perf.TotalJobs += c.total
+	perf.SuccessfulJobs += c.successful
+	perf.FailedJobs += c.failed
+	if jobs {
+		perf.PoPCPassRate = stats.PoPCPassRate
+		perf.AvgLatency = stats.AvgLatency
+	}
+	if audits {
+		perf.DAReliability = stats.DAReliability
+	}
+	perf.LastUpdated = now
+
+	if w.IsNewcomer && perf.TotalJobs >= h.newcomerJobs {
+		w.IsNewcomer = false
+	}
+}
+
+// stats computes a worker's windowed performance and reports whether it had
+// any job outcomes and any audits in the window
+func (h *History) stats(addr common.Address, now time.Time) (types.PerformanceStats, bool, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := types.PerformanceStats{LastUpdated: now}
+	var weight, popc, latency float64
+	for _, o := range h.outcomes[addr] {
+		if !h.inWindow(o.At, now) {
+			continue
+		}
+
+		stats.TotalJobs++
+		if o.Success {
+			stats.SuccessfulJobs++
+		} else {
+			stats.FailedJobs++
+		}
+
+		wt := h.weight(now.Sub(o.At))
+		weight += wt
+		latency += wt * o.Latency
+		if o.PoPCPassed {
+			popc += wt
+		}
+	}
+	if weight > 0 {
+		stats.PoPCPassRate = popc / weight
+		stats.AvgLatency = latency / weight
+	}
+
+	var auditWeight, served float64
+	for _, a := range h.audits[addr] {
+		if !h.inWindow(a.At, now) {
+			continue
+		}
+		wt := h.weight(now.Sub(a.At))
+		auditWeight += wt
+		if a.Passed {
+			served += wt
+		}
+	}
+	if auditWeight > 0 {
+		stats.DAReliability = served / auditWeight
+	}
+	return stats, weight > 0, auditWeight > 0
+}
+
+// Prune drops outcomes and audits that have left the window ending at now
+// and returns how many were removed
+func (h *History) Prune(now time.Time) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	removed := 0
+	for addr, outcomes := range h.outcomes {
+		kept := outcomes[:0]
+		for _, o := range outcomes {
+			if h.inWindow(o.At, now) || o.At.After(now) {
+				kept = append(kept, o)
+			}
+		}
+		removed += len(outcomes) - len(kept)
+		if len(kept) == 0 {
+			delete(h.outcomes, addr)
+		} else {
+			h.outcomes[addr] = kept
+		}
+	}
+	for addr, audits := range h.audits {
+		kept := audits[:0]
+		for _, a := range audits {
+			if h.inWindow(a.At, now) || a.At.After(now) {
+				kept = append(kept, a)
+			}
+		}
+		removed += len(audits) - len(kept)
+		if len(kept) == 0 {
+			delete(h.audits, addr)
+		} else {
+			h.audits[addr] = kept
+		}
+	}
+	return removed
+}
+
+// inWindow reports whether a record made at t falls in the window ending
+// at now
+func (h *History) inWindow(t, now time.Time) bool {
+	return !t.After(now) && now.Sub(t) < h.window
+}
+
+// weight is the decay weight of an outcome of the given age
+func (h *History) weight(age time.Duration) float64 {
+	if h.halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(h.halfLife))
+}
@@ -0,0 +1,180 @@
+package asr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// historyConfig returns a 30-day window with the given half-life in days
+func historyConfig(halfLife float64) config.ASRConfig {
+	cfg := config.DefaultConfig().ASR
+	cfg.PerformanceWindow = 30
+	cfg.PerformanceHalfLife = halfLife
+	return cfg
+}
+
+func TestHistory_StatsWithoutDecay(t *testing.T) {
+	h := NewHistory(historyConfig(0))
+	w := testWorker(0, 0.9)
+	now := time.Unix(1_700_000_000, 0)
+
+	h.Record(JobOutcome{JobID: "a", Worker: w.Address, Success: true, PoPCPassed: true, Latency: 10, At: now.Add(-2 * Day)})
+	h.Record(JobOutcome{JobID: "b", Worker: w.Address, Success: true, PoPCPassed: true, Latency: 20, At: now.Add(-Day)})
+	h.Record(JobOutcome{JobID: "c", Worker: w.Address, Success: false, PoPCPassed: false, Latency: 30, At: now})
+	h.RecordAudit(AuditOutcome{JobID: "a", Worker: w.Address, Shard: 0, Passed: true, At: now.Add(-2 * Day)})
+	h.RecordAudit(AuditOutcome{JobID: "a", Worker: w.Address, Shard: 1, Passed: false, At: now.Add(-2 * Day)})
+	h.RecordAudit(AuditOutcome{JobID: "c", Worker: w.Address, Shard: 0, Passed: true, At: now})
+
+	stats, ok := h.Stats(w.Address, now)
+	require.True(t, ok)
+	assert.Equal(t, 3, stats.TotalJobs)
+	assert.Equal(t, 2, stats.SuccessfulJobs)
+	assert.Equal(t, 1, stats.FailedJobs)
+	assert.InDelta(t, 2.0/3, stats.PoPCPassRate, 1e-9)
+	assert.InDelta(t, 2.0/3, stats.DAReliability, 1e-9)
+	assert.InDelta(t, 20.0, stats.AvgLatency, 1e-9)
+	assert.Equal(t, now, stats.LastUpdated)
+}
+
+func TestHistory_OldOutcomesLeaveWindow(t *testing.T) {
+	h := NewHistory(historyConfig(0))
+	w := testWorker(0, 0.9)
+	now := time.Unix(1_700_000_000, 0)
+
+	for i := 0; i < 10; i++ {
+		h.Record(JobOutcome{Worker: w.Address, At: now.Add(-40 * Day)})
+	}
+	h.Record(JobOutcome{Worker: w.Address, Success: true, PoPCPassed: true, At: now.Add(-Day)})
+	h.RecordAudit(AuditOutcome{Worker: w.Address, At: now.Add(-40 * Day)})
+
+	stats, ok := h.Stats(w.Address, now)
+	require.True(t, ok)
+	assert.Equal(t, 1, stats.TotalJobs)
+	assert.Equal(t, 1.0, stats.PoPCPassRate)
+	assert.Len(t, h.Outcomes(w.Address, now), 1)
+
+	assert.Equal(t, 11, h.Prune(now))
+	assert.Equal(t, 0, h.Prune(now))
+	assert.Len(t, h.Outcomes(w.Address, now), 1)
+}
+
+func TestHistory_DecayFavoursRecentOutcomes(t *testing.T) {
+	h := NewHistory(historyConfig(7))
+	reformed := testWorker(0, 0.9)
+	lapsed := testWorker(1, 0.9)
+	now := time.Unix(1_700_000_000, 0)
+
+	// Both have one failure and one pass, a fortnight apart
+	h.Record(JobOutcome{Worker: reformed.Address, At: now.Add(-14 * Day)})
+	h.Record(JobOutcome{Worker: reformed.Address, Success: true, PoPCPassed: true, At: now})
+	h.Record(JobOutcome{Worker: lapsed.Address, Success: true, PoPCPassed: true, At: now.Add(-14 * Day)})
+	h.Record(JobOutcome{Worker: lapsed.Address, At: now})
+
+	good, ok := h.Stats(reformed.Address, now)
+	require.True(t, ok)
+	bad, ok := h.Stats(lapsed.Address, now)
+	require.True(t, ok)
+
+	// Two half-lives: weights 1 and 0.25
+	assert.InDelta(t, 0.8, good.PoPCPassRate, 1e-9)
+	assert.InDelta(t, 0.2, bad.PoPCPassRate, 1e-9)
+	assert.Equal(t, good.TotalJobs, bad.TotalJobs)
+}
+
+func TestHistory_Refresh(t *testing.T) {
+	h := NewHistory(historyConfig(7))
+	w := testWorker(0, 0.9)
+	w.Performance.TotalJobs = 500
+	now := time.Unix(1_700_000_000, 0)
+
+	// Nothing recorded: rates and lifetime counts are kept
+	h.Refresh(w, now)
+	assert.Equal(t, 500, w.Performance.TotalJobs)
+	assert.Equal(t, 0.9, w.Performance.PoPCPassRate)
+
+	h.Record(JobOutcome{Worker: w.Address, Success: true, PoPCPassed: false, Latency: 5, At: now})
+	h.Refresh(w, now)
+	assert.Equal(t, 501, w.Performance.TotalJobs)
+	assert.Equal(t, 1, w.Performance.SuccessfulJobs)
+	assert.Equal(t, 0.0, w.Performance.PoPCPassRate)
+	assert.Equal(t, 0.9, w.Performance.DAReliability, "no audits yet")
+	assert.Equal(t, 0.9, w.Performance.Uptime)
+
+	h.RecordAudit(AuditOutcome{Worker: w.Address, Passed: true, At: now})
+	h.RecordAudit(AuditOutcome{Worker: w.Address, Passed: false, At: now})
+	h.Refresh(w, now)
+	assert.Equal(t, 0.5, w.Performance.DAReliability)
+}
+
+func TestHistory_RefreshKeepsLifetimeCounts(t *testing.T) {
+	cfg := historyConfig(0)
+	cfg.PerformanceWindow = 7
+	h := NewHistory(cfg)
+	w := testWorker(0, 0.9)
+	now := time.Unix(1_700_000_000, 0)
+
+	// A low-volume worker: one job per window, refreshed between jobs
+	for i := 0; i < 3; i++ {
+		at := now.Add(time.Duration(i) * 8 * Day)
+		h.Record(JobOutcome{Worker: w.Address, Success: i != 1, PoPCPassed: true, At: at})
+		h.Refresh(w, at)
+		h.Refresh(w, at.Add(7*Day))
+	}
+
+	// Counts accumulate even though each window held a single job, and an
+	// empty window does not zero them
+	assert.Equal(t, 3, w.Performance.TotalJobs)
+	assert.Equal(t, 2, w.Performance.SuccessfulJobs)
+	assert.Equal(t, 1, w.Performance.FailedJobs)
+
+	stats, ok := h.Stats(w.Address, now.Add(23*Day))
+	assert.False(t, ok)
+	assert.Equal(t, 0, stats.TotalJobs)
+}
+
+func TestHistory_RefreshNewcomer(t *testing.T) {
+	cfg := historyConfig(0)
+	h := NewHistory(cfg)
+	w := testWorker(0, 0.8)
+	w.IsNewcomer = true
+	w.Performance.TotalJobs = cfg.NewcomerJobs - 2
+	now := time.Unix(1_700_000_000, 0)
+
+	h.Record(JobOutcome{Worker: w.Address, Success: true, PoPCPassed: true, At: now})
+	h.Refresh(w, now)
+	assert.True(t, w.IsNewcomer)
+
+	h.Record(JobOutcome{Worker: w.Address, Success: false, At: now})
+	h.Refresh(w, now)
+	assert.Equal(t, cfg.NewcomerJobs, w.Performance.TotalJobs)
+	assert.False(t, w.IsNewcomer)
+}
+
+func TestHistory_AuditsAlone(t *testing.T) {
+	h := NewHistory(historyConfig(0))
+	w := testWorker(0, 0.9)
+	now := time.Unix(1_700_000_000, 0)
+
+	h.RecordAudit(AuditOutcome{Worker: w.Address, Passed: true, At: now})
+	stats, ok := h.Stats(w.Address, now)
+	require.True(t, ok)
+	assert.Equal(t, 0, stats.TotalJobs)
+	assert.Equal(t, 1.0, stats.DAReliability)
+	assert.Empty(t, h.Outcomes(w.Address, now))
+}
+
+func TestHistory_IgnoresFutureOutcomes(t *testing.T) {
+	h := NewHistory(historyConfig(0))
+	w := testWorker(0, 0.9)
+	now := time.Unix(1_700_000_000, 0)
+
+	h.Record(JobOutcome{Worker: w.Address, At: now.Add(time.Hour)})
+
+	_, ok := h.Stats(w.Address, now)
+	assert.False(t, ok)
+	assert.Equal(t, 0, h.Prune(now))
+}
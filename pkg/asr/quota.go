@@ -0,0 +1,151 @@
+package asr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrAllAtQuota is returned when every candidate worker has reached q_max
+var ErrAllAtQuota = errors.New("asr: every candidate worker is at quota")
+
+// QuotaLedger tracks each worker's share of job assignments within the
+// current epoch. A worker whose share has reached ASRConfig.MaxQuota is at
+// quota and is excluded from selection until the next epoch.
+type QuotaLedger struct {
+	mu          sync.Mutex
+	maxQuota    float64
+	epochLength uint64
+	minTotal    int // Assignments before shares are meaningful
+	epoch       uint64
+	total       int
+	counts      map[common.Address]int
+}
+
+// NewQuotaLedger creates a ledger using ASRConfig.MaxQuota and
+// ConsensusConfig.EpochLength
+func NewQuotaLedger(asrCfg config.ASRConfig, consensus config.ConsensusConfig) *QuotaLedger {
+	epochLength := uint64(1)
+	if consensus.EpochLength > 0 {
+		epochLength = uint64(consensus.EpochLength)
+	}
+
+	// With fewer than 1/q_max assignments even a perfectly fair split puts
+	// someone over quota, so shares are only enforced past that point
+	minTotal := 1
+	if asrCfg.MaxQuota > 0 && asrCfg.MaxQuota < 1 {
+		minTotal = int(math.Ceil(1 / asrCfg.MaxQuota))
+	}
+
+	return &QuotaLedger{
+		maxQuota:    asrCfg.MaxQuota,
+		epochLength: epochLength,
+		minTotal:    minTotal,
+		counts:      make(map[common.Address]int),
+	}
+}
+
+// EpochOf returns the epoch a block belongs to
+func (l *QuotaLedger) EpochOf(block uint64) uint64 {
+	return block / l.epochLength
+}
+
+// Epoch returns the ledger's current epoch
+func (l *QuotaLedger) Epoch() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.epoch
+}
+
+// Advance moves the ledger to the epoch of block, resetting all shares when
+// a new epoch begins. It reports whether a reset happened.
+func (l *QuotaLedger) Advance(block uint64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.advance(block)
+}
+
+// Record counts an assignment to a worker at block and refreshes the
+// worker's QuotaUsed
+func (l *QuotaLedger) Record(w *types.Worker, block uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(block)
+	l.counts[w.Address]++
+	l.total++
+	w.QuotaUsed = l.share(w.Address)
+}
+
+// Share returns a worker's share of this epoch's assignments
+func (l *QuotaLedger) Share(addr common.Address) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.share(addr)
+}
+
+// AtQuota reports whether a worker has reached q_max for this epoch
+func (l *QuotaLedger) AtQuota(addr common.Address) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxQuota <= 0 || l.total < l.minTotal {
+		return false
+	}
+	return l.share(addr) >= l.maxQuota
+}
+
+// Apply refreshes QuotaUsed on every given worker, e.g. after an epoch reset
+func (l *QuotaLedger) Apply(workers []*types.Worker) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, w := range workers {
+		w.QuotaUsed = l.share(w.Address)
+	}
+}
+
+// Filter removes workers at quota. If every worker is at quota, which can
+// happen when there are fewer than 1/q_max of them, it returns
+// ErrAllAtQuota and leaves the caller to decide whether to wait for the next
+// epoch or relax the quota.
+func (l *QuotaLedger) Filter(workers []*types.Worker) ([]*types.Worker, error) {
+	under := make([]*types.Worker, 0, len(workers))
+	for _, w := range workers {
+		if !l.AtQuota(w.Address) {
+			under = append(under, w)
+		}
+	}
+	if len(under) == 0 && len(workers) > 0 {
+		return nil, fmt.Errorf("%w: %d workers", ErrAllAtQuota, len(workers))
+	}
+	return under, nil
+}
+
+func (l *QuotaLedger) advance(block uint64) bool {
+	// Blocks from past epochs never rewind the ledger
+	epoch := l.EpochOf(block)
+	if epoch <= l.epoch {
+		return false
+	}
+
+	l.epoch = epoch
+	l.total = 0
+	l.counts = make(map[common.Address]int)
+	return true
+}
+
+func (l *QuotaLedger) share(addr common.Address) float64 {
+	if l.total == 0 {
+		return 0
+	}
+	return float64(l.counts[addr]) / float64(l.total)
+}
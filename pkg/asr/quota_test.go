@@ -0,0 +1,145 @@
+package asr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLedger() *QuotaLedger {
+	cfg := config.DefaultConfig()
+	return NewQuotaLedger(cfg.ASR, cfg.Consensus)
+}
+
+func TestQuotaLedger_Share(t *testing.T) {
+	l := newLedger()
+	a := testWorker(0, 0.9)
+	b := testWorker(1, 0.9)
+
+	l.Record(a, 10)
+	l.Record(a, 11)
+	l.Record(b, 12)
+	l.Record(b, 13)
+
+	assert.Equal(t, 0.5, l.Share(a.Address))
+	assert.Equal(t, 0.5, b.QuotaUsed)
+	assert.Equal(t, 0.0, l.Share(common.HexToAddress("0xff")))
+}
+
+func TestQuotaLedger_AtQuota(t *testing.T) {
+	l := newLedger() // q_max = 12.5%, so shares count after 8 assignments
+	big := testWorker(0, 0.9)
+
+	for i := 0; i < 7; i++ {
+		l.Record(big, 1)
+	}
+	assert.False(t, l.AtQuota(big.Address), "too few assignments to enforce")
+
+	l.Record(big, 1)
+	assert.True(t, l.AtQuota(big.Address))
+
+	// Spreading work over others brings the share back under q_max
+	for i := 1; i <= 64; i++ {
+		l.Record(testWorker(i, 0.9), 1)
+	}
+	assert.Less(t, l.Share(big.Address), 0.125)
+	assert.False(t, l.AtQuota(big.Address))
+}
+
+func TestQuotaLedger_EpochReset(t *testing.T) {
+	l := newLedger() // 100 blocks per epoch
+	w := testWorker(0, 0.9)
+
+	for i := 0; i < 10; i++ {
+		l.Record(w, 50)
+	}
+	require.True(t, l.AtQuota(w.Address))
+	assert.Equal(t, uint64(0), l.Epoch())
+
+	// Same epoch: no reset
+	assert.False(t, l.Advance(99))
+
+	assert.True(t, l.Advance(100))
+	assert.Equal(t, uint64(1), l.Epoch())
+	assert.False(t, l.AtQuota(w.Address))
+
+	l.Apply([]*types.Worker{w})
+	assert.Equal(t, 0.0, w.QuotaUsed)
+
+	// Old blocks never rewind the epoch
+	assert.False(t, l.Advance(20))
+	assert.Equal(t, uint64(1), l.Epoch())
+}
+
+func TestQuotaLedger_FilterReportsAllCapped(t *testing.T) {
+	l := newLedger()
+	a := testWorker(0, 0.9)
+	b := testWorker(1, 0.9)
+	for i := 0; i < 8; i++ {
+		l.Record(a, 1)
+		l.Record(b, 1)
+	}
+
+	// Both hold 50% > q_max; the caller decides what to do about it
+	_, err := l.Filter([]*types.Worker{a, b})
+	assert.ErrorIs(t, err, ErrAllAtQuota)
+
+	c := testWorker(2, 0.9)
+	under, err := l.Filter([]*types.Worker{a, b, c})
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Worker{c}, under)
+}
+
+func TestRouter_AssignEnforcesQuota(t *testing.T) {
+	cfg := config.DefaultConfig()
+	r := NewRouter(cfg.ASR)
+	l := NewQuotaLedger(cfg.ASR, cfg.Consensus)
+	r.SetQuotaLedger(l)
+
+	var workers []*types.Worker
+	for i := 0; i < 16; i++ {
+		workers = append(workers, testWorker(i, 0.5))
+	}
+	// One dominant worker would win most jobs without quota
+	workers[0].Reputation = 1
+	workers[0].Performance = types.PerformanceStats{PoPCPassRate: 1, DAReliability: 1, Uptime: 1}
+
+	for i := 0; i < 200; i++ {
+		job := testJob(fmt.Sprintf("job-%d", i))
+		w, err := r.Assign(job, workers, common.HexToHash("0x70"), 5)
+		require.NoError(t, err)
+		assert.Equal(t, types.JobStatusAssigned, job.Status)
+		assert.Equal(t, w.Address, job.Worker)
+	}
+
+	// The dominant worker can exceed q_max by at most one assignment
+	assert.LessOrEqual(t, l.Share(workers[0].Address), 0.125+1.0/200)
+
+	l.Apply(workers)
+	assert.Equal(t, l.Share(workers[0].Address), workers[0].QuotaUsed)
+}
+
+func TestRouter_AssignReportsAllAtQuota(t *testing.T) {
+	l := newLedger()
+	r := NewRouter(config.DefaultConfig().ASR)
+	r.SetQuotaLedger(l)
+
+	a := testWorker(0, 0.9)
+	b := testWorker(1, 0.9)
+	for i := 0; i < 8; i++ {
+		l.Record(a, 1)
+		l.Record(b, 1)
+	}
+
+	// With only capped workers the job is not silently assigned
+	job := testJob("job-capped")
+	_, err := r.Assign(job, []*types.Worker{a, b}, common.HexToHash("0x71"), 1)
+	assert.ErrorIs(t, err, ErrAllAtQuota)
+	assert.ErrorIs(t, err, ErrNoCandidates)
+	assert.Equal(t, types.JobStatusPending, job.Status)
+}
@@ -0,0 +1,280 @@
+// Package asr implements the Auto-Selection Router that assigns compute jobs
+// to workers
+package asr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Domain tags for selection seed derivation
+const (
+	selectionDomain = "axionax/asr/select/v1"
+	replicaDomain   = "axionax/asr/replica/v1"
+)
+
+// LatencyReference is the average latency, in seconds, that scores 0.5 on
+// the latency component
+const LatencyReference = 30.0
+
+// ErrNoCandidates is returned when no worker can be selected for a job
+var ErrNoCandidates = errors.New("asr: no candidate workers")
+
+// Weights are the relative weights of the score components
+type Weights struct {
+	Reputation    float64 `json:"reputation"`
+	PoPCPassRate  float64 `json:"popc_pass_rate"`
+	DAReliability float64 `json:"da_reliability"`
+	Latency       float64 `json:"latency"`
+	Uptime        float64 `json:"uptime"`
+	Region        float64 `json:"region"` // Bonus for matching the job's region
+}
+
+// DefaultWeights returns the default score weights, which sum to 1
+func DefaultWeights() Weights {
+	return Weights{
+		Reputation:    0.30,
+		PoPCPassRate:  0.25,
+		DAReliability: 0.15,
+		Latency:       0.10,
+		Uptime:        0.15,
+		Region:        0.05,
+	}
+}
+
+// Candidate is a worker with its score for a job
+type Candidate struct {
+	Worker *types.Worker `json:"worker"`
+	Score  float64       `json:"score"`
+}
+
+// Router scores workers for jobs, keeps the top K and selects one
+type Router struct {
+	cfg     config.ASRConfig
+	weights Weights
+	quota   *QuotaLedger
+}
+
+// NewRouter creates a router with default weights
+func NewRouter(cfg config.ASRConfig) *Router {
+	return &Router{
+		cfg:     cfg,
+		weights: DefaultWeights(),
+	}
+}
+
+// SetWeights replaces the score weights
+func (r *Router) SetWeights(w Weights) {
+	r.weights = w
+}
+
+// SetQuotaLedger enables per-epoch quota enforcement during selection
+func (r *Router) SetQuotaLedger(l *QuotaLedger) {
+	r.quota = l
+}
+
+// Score returns a worker's score for a job spec. Higher is better.
+// Newcomers receive ASRConfig.NewcomerBoost on top of their base score.
+func (r *Router) Score(w *types.Worker, spec types.JobSpecs) float64 {
+	score := r.baseScore(w, spec)
+	if r.isNewcomer(w) {
+		score += r.cfg.NewcomerBoost
+	}
+	return score
+}
+
+// isNewcomer reports whether a worker still qualifies for the newcomer
+// boost. The flag itself is cleared by History.Refresh.
+func (r *Router) isNewcomer(w *types.Worker) bool {
+	return w.IsNewcomer && w.Performance.TotalJobs < r.cfg.NewcomerJobs
+}
+
+// baseScore is the weighted performance score without boosts
+func (r *Router) baseScore(w *types.Worker, spec types.JobSpecs) float64 {
+	perf := w.Performance
+
+	latency := 1 / (1 + perf.AvgLatency/LatencyReference)
+	region := 0.0
+	if spec.Region != "" && strings.EqualFold(spec.Region, w.Specs.Region) {
+		region = 1
+	}
+
+	return r.weights.Reputation*clamp01(w.Reputation) +
+		r.weights.PoPCPassRate*clamp01(perf.PoPCPassRate) +
+		r.weights.DAReliability*clamp01(perf.DAReliability) +
+		r.weights.Latency*latency +
+		r.weights.Uptime*clamp01(perf.Uptime) +
+		r.weights.Region*region
+}
+
+// Rank scores every active worker and returns them best first. Ties are
+// broken by address so the ranking does not depend on input order.
+func (r *Router) Rank(workers []*types.Worker, spec types.JobSpecs) []Candidate {
+	ranked := make([]Candidate, 0, len(workers))
+	for _, w := range workers {
+		if w.Status != types.WorkerStatusActive {
+			continue
+		}
+		ranked = append(ranked, Candidate{Worker: w, Score: r.Score(w, spec)})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Worker.Address.Hex() < ranked[j].Worker.Address.Hex()
+	})
+	return ranked
+}
+
+// TopK returns the K best-ranked workers for a job spec. With
+// anti-collusion enabled no ASN or organization holds more than
+// MaxPerOperator of the K slots.
+func (r *Router) TopK(workers []*types.Worker, spec types.JobSpecs) []Candidate {
+	top, _ := r.splitTopK(r.Rank(workers, spec))
+	return top
+}
+
+// splitTopK splits a ranking into the top K and the rest. With
+// anti-collusion enabled, workers beyond their operator's MaxPerOperator cap
+// are dropped first, so exploration cannot hand them the slots the cap
+// denied.
+func (r *Router) splitTopK(ranked []Candidate) (top, rest []Candidate) {
+	if r.cfg.AntiCollusionEnabled && r.cfg.MaxPerOperator > 0 {
+		ranked = diversify(ranked, r.cfg.MaxPerOperator)
+	}
+	if r.cfg.TopK > 0 && len(ranked) > r.cfg.TopK {
+		return ranked[:r.cfg.TopK], ranked[r.cfg.TopK:]
+	}
+	return ranked, nil
+}
+
+// Select filters out workers that cannot run the job and, with a quota
+// ledger, workers at quota; if every eligible worker is at quota the error
+// wraps ErrAllAtQuota. With probability ExplorationRate it then picks
+// uniformly among the remaining workers outside the top K; otherwise it
+// picks from the top K with probability proportional to score. The pick is
+// reproducible from the seed. If no worker is eligible the error is a
+// *NoEligibleError explaining why. Select does not modify the workers.
+func (r *Router) Select(job *types.Job, workers []*types.Worker, seed common.Hash) (*types.Worker, error) {
+	return r.SelectAvoiding(job, workers, seed, nil)
+}
+
+// SelectAvoiding is Select restricted to workers that do not conflict with
+// avoid, for example the job's primary worker and validators. A nil set
+// excludes nothing.
+func (r *Router) SelectAvoiding(job *types.Job, workers []*types.Worker, seed common.Hash, avoid *OperatorSet) (*types.Worker, error) {
+	eligible, rejected := FilterEligible(workers, job.Specs)
+	if len(eligible) == 0 && len(rejected) > 0 {
+		return nil, &NoEligibleError{JobID: job.ID, Rejected: rejected}
+	}
+	if avoid != nil {
+		eligible = avoid.without(eligible)
+		if len(eligible) == 0 {
+			return nil, fmt.Errorf("%w: job %s: every eligible worker shares an operator with the job", ErrNoCandidates, job.ID)
+		}
+	}
+	if r.quota != nil {
+		var err error
+		if eligible, err = r.quota.Filter(eligible); err != nil {
+			return nil, fmt.Errorf("%w: job %s: %w", ErrNoCandidates, job.ID, err)
+		}
+	}
+
+	ranked := r.Rank(eligible, job.Specs)
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("%w: job %s", ErrNoCandidates, job.ID)
+	}
+
+	top, rest := r.splitTopK(ranked)
+
+	stream := randomness.NewStream(randomness.DeriveSeed(selectionDomain, seed.Bytes(), []byte(job.ID)))
+	// The exploration draw is always consumed so replays stay aligned
+	explore := stream.Float64() < r.cfg.ExplorationRate
+	if explore && len(rest) > 0 {
+		return rest[stream.Intn(len(rest))].Worker, nil
+	}
+	return pickWeighted(stream, top).Worker, nil
+}
+
+// SelectReplica selects a worker to re-execute a job alongside its primary.
+// The replica never shares an address with the primary or the job's
+// validators, nor an ASN or organization with the primary, since it exists
+// to check the primary independently; once the primary declares its
+// operator, a worker that does not declare both is never chosen. With
+// anti-collusion enabled a worker outside the primary's region is preferred
+// when one is eligible.
+func (r *Router) SelectReplica(job *types.Job, primary *types.Worker, validators []common.Address, workers []*types.Worker, seed common.Hash) (*types.Worker, error) {
+	avoid := NewOperatorSet()
+	avoid.AddWorker(primary)
+	for _, v := range validators {
+		avoid.AddAddress(v)
+	}
+
+	replicaSeed := randomness.DeriveSeed(replicaDomain, seed.Bytes())
+	if r.cfg.AntiCollusionEnabled {
+		if w, err := r.SelectAvoiding(job, differentRegion(workers, primary.Specs.Region), replicaSeed, avoid); err == nil {
+			return w, nil
+		}
+	}
+	return r.SelectAvoiding(job, workers, replicaSeed, avoid)
+}
+
+// Assign selects a worker for a pending job at the given block, assigns the
+// job to it and records the assignment against the worker's quota
+func (r *Router) Assign(job *types.Job, workers []*types.Worker, seed common.Hash, block uint64) (*types.Worker, error) {
+	if r.quota != nil {
+		r.quota.Advance(block)
+	}
+
+	w, err := r.Select(job, workers, seed)
+	if err != nil {
+		return nil, err
+	}
+	if err := job.Assign(w.Address); err != nil {
+		return nil, err
+	}
+
+	if r.quota != nil {
+		r.quota.Record(w, block)
+	}
+	return w, nil
+}
+
+// pickWeighted draws a candidate with probability proportional to its score,
+// falling back to a uniform draw when every score is zero
+func pickWeighted(stream *randomness.Stream, candidates []Candidate) Candidate {
+	total := 0.0
+	for _, c := range candidates {
+		total += c.Score
+	}
+	if total <= 0 {
+		return candidates[stream.Intn(len(candidates))]
+	}
+
+	target := stream.Float64() * total
+	for _, c := range candidates {
+		target -= c.Score
+		if target < 0 {
+			return c
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
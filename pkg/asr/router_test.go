@@ -0,0 +1,157 @@
+package asr
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWorker returns an active worker whose performance scales with quality
+func testWorker(i int, quality float64) *types.Worker {
+	return &types.Worker{
+		Address:    common.BigToAddress(big.NewInt(int64(i + 1))),
+		Status:     types.WorkerStatusActive,
+		Reputation: quality,
+		Specs: types.WorkerSpecs{
+			GPUs:         []types.GPUSpec{{Model: "NVIDIA RTX 4090", VRAM: 24, Count: 1}},
+			Region:       "us-west",
+			ASN:          fmt.Sprintf("AS%d", 1000+i),
+			Organization: fmt.Sprintf("org-%d", i),
+		},
+		Performance: types.PerformanceStats{
+			PoPCPassRate:  quality,
+			DAReliability: quality,
+			AvgLatency:    10,
+			Uptime:        quality,
+		},
+	}
+}
+
+func testJob(id string) *types.Job {
+	return &types.Job{
+		ID:     id,
+		Status: types.JobStatusPending,
+		Specs:  types.JobSpecs{GPU: "NVIDIA RTX 4090", VRAM: 24, Region: "us-west"},
+	}
+}
+
+func TestDefaultWeights_SumToOne(t *testing.T) {
+	w := DefaultWeights()
+	sum := w.Reputation + w.PoPCPassRate + w.DAReliability + w.Latency + w.Uptime + w.Region
+	assert.InDelta(t, 1.0, sum, 1e-9)
+}
+
+func TestRouter_Score(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	spec := types.JobSpecs{Region: "us-west"}
+
+	good := testWorker(0, 0.99)
+	bad := testWorker(1, 0.5)
+	assert.Greater(t, r.Score(good, spec), r.Score(bad, spec))
+
+	// Lower latency scores higher
+	fast := testWorker(2, 0.9)
+	slow := testWorker(3, 0.9)
+	slow.Performance.AvgLatency = 120
+	assert.Greater(t, r.Score(fast, spec), r.Score(slow, spec))
+
+	// Matching region scores higher
+	away := testWorker(4, 0.9)
+	away.Specs.Region = "eu-central"
+	assert.Greater(t, r.Score(fast, spec), r.Score(away, spec))
+
+	// Perfect worker with zero latency scores 1
+	perfect := testWorker(5, 1)
+	perfect.Performance.AvgLatency = 0
+	assert.InDelta(t, 1.0, r.Score(perfect, spec), 1e-9)
+}
+
+func TestRouter_RankSkipsInactive(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	active := testWorker(0, 0.8)
+	slashed := testWorker(1, 0.99)
+	slashed.Status = types.WorkerStatusSlashed
+
+	ranked := r.Rank([]*types.Worker{slashed, active}, types.JobSpecs{})
+	require.Len(t, ranked, 1)
+	assert.Equal(t, active, ranked[0].Worker)
+}
+
+func TestRouter_TopK(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 3
+	r := NewRouter(cfg)
+
+	var workers []*types.Worker
+	for i := 0; i < 10; i++ {
+		workers = append(workers, testWorker(i, 0.5+float64(i)*0.05))
+	}
+
+	top := r.TopK(workers, types.JobSpecs{})
+	require.Len(t, top, 3)
+	assert.Equal(t, workers[9], top[0].Worker)
+	assert.Equal(t, workers[8], top[1].Worker)
+	assert.Equal(t, workers[7], top[2].Worker)
+}
+
+func TestRouter_SelectReproducible(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	var workers []*types.Worker
+	for i := 0; i < 20; i++ {
+		workers = append(workers, testWorker(i, 0.8))
+	}
+	job := testJob("job-select")
+	seed := common.HexToHash("0x50")
+
+	first, err := r.Select(job, workers, seed)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		again, err := r.Select(job, workers, seed)
+		require.NoError(t, err)
+		assert.Equal(t, first.Address, again.Address)
+	}
+}
+
+func TestRouter_SelectFavorsHigherScores(t *testing.T) {
+	cfg := config.DefaultConfig().ASR
+	cfg.TopK = 2
+	r := NewRouter(cfg)
+	strong := testWorker(0, 1)
+	weak := testWorker(1, 0.1)
+	weak.Performance.AvgLatency = 300
+
+	counts := make(map[common.Address]int)
+	for i := 0; i < 1000; i++ {
+		w, err := r.Select(testJob(fmt.Sprintf("job-%d", i)), []*types.Worker{strong, weak}, common.HexToHash("0x51"))
+		require.NoError(t, err)
+		counts[w.Address]++
+	}
+
+	assert.Greater(t, counts[strong.Address], counts[weak.Address]*3)
+}
+
+func TestRouter_SelectNoCandidates(t *testing.T) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	_, err := r.Select(testJob("job-empty"), nil, common.HexToHash("0x52"))
+	assert.ErrorIs(t, err, ErrNoCandidates)
+}
+
+func BenchmarkRouter_Select(b *testing.B) {
+	r := NewRouter(config.DefaultConfig().ASR)
+	var workers []*types.Worker
+	for i := 0; i < 1000; i++ {
+		workers = append(workers, testWorker(i, float64(i%100)/100))
+	}
+	job := testJob("bench")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Select(job, workers, common.HexToHash("0x53"))
+	}
+}
@@ -2,6 +2,8 @@
 package config
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/spf13/viper"
@@ -42,9 +44,10 @@ type NetworkConfig struct {
 
 // PoPCConfig defines Proof-of-Probabilistic-Checking parameters
 type PoPCConfig struct {
-	SampleSize         int           `mapstructure:"sample_size"`     // s, default 600-1500
-	RedundancyRate     float64       `mapstructure:"redundancy_rate"` // β, default 2-3%
-	MinConfidence      float64       `mapstructure:"min_confidence"`  // Required detection probability
+	SampleSize         int           `mapstructure:"sample_size"`         // s, default 600-1500
+	RedundancyRate     float64       `mapstructure:"redundancy_rate"`     // β, default 2-3%
+	MinConfidence      float64       `mapstructure:"min_confidence"`      // Required detection probability
+	DetectableFraction float64       `mapstructure:"detectable_fraction"` // Smallest corrupted fraction to detect
 	StratifiedSampling bool          `mapstructure:"stratified_sampling"`
 	AdaptiveEscalation bool          `mapstructure:"adaptive_escalation"`
 	FraudWindowTime    time.Duration `mapstructure:"fraud_window_time"` // ~3600s
@@ -52,12 +55,15 @@ type PoPCConfig struct {
 
 // ASRConfig defines Auto-Selection Router parameters
 type ASRConfig struct {
-	TopK                 int     `mapstructure:"top_k"`              // K, default 64
-	MaxQuota             float64 `mapstructure:"max_quota"`          // q_max, default 10-15%
-	ExplorationRate      float64 `mapstructure:"exploration_rate"`   // ε, default 5%
-	NewcomerBoost        float64 `mapstructure:"newcomer_boost"`     // Bonus for newcomers
-	PerformanceWindow    int     `mapstructure:"performance_window"` // Days to consider, 7-30
+	TopK                 int     `mapstructure:"top_k"`                 // K, default 64
+	MaxQuota             float64 `mapstructure:"max_quota"`             // q_max, default 10-15%
+	ExplorationRate      float64 `mapstructure:"exploration_rate"`      // ε, default 5%
+	NewcomerBoost        float64 `mapstructure:"newcomer_boost"`        // Bonus for newcomers
+	NewcomerJobs         int     `mapstructure:"newcomer_jobs"`         // Jobs before a worker stops being a newcomer
+	PerformanceWindow    int     `mapstructure:"performance_window"`    // Days to consider, 7-30
+	PerformanceHalfLife  float64 `mapstructure:"performance_half_life"` // Days for an outcome's weight to halve, 0 disables decay
 	AntiCollusionEnabled bool    `mapstructure:"anti_collusion_enabled"`
+	MaxPerOperator       int     `mapstructure:"max_per_operator"` // Top-K slots one ASN or organization may hold
 }
 
 // PPCConfig defines Posted Price Controller parameters
@@ -79,6 +85,7 @@ type DAConfig struct {
 	AvailabilityWindow time.Duration `mapstructure:"availability_window"` // Δt_DA
 	ReplicationFactor  int           `mapstructure:"replication_factor"`
 	LiveAuditEnabled   bool          `mapstructure:"live_audit_enabled"`
+	DiskQuota          int           `mapstructure:"disk_quota"` // in MB, 0 for unlimited
 }
 
 // VRFConfig defines Verifiable Random Function parameters
@@ -93,8 +100,8 @@ type ConsensusConfig struct {
 	EpochLength       int           `mapstructure:"epoch_length"` // Blocks per epoch
 	MinValidatorStake string        `mapstructure:"min_validator_stake"`
 	MaxValidators     int           `mapstructure:"max_validators"`
-	SlashingRate      float64       `mapstructure:"slashing_rate"`      // For false pass
-	FalsePassPenalty  int           `mapstructure:"false_pass_penalty"` // basis points, ≥500
+	SlashingRate      float64       `mapstructure:"slashing_rate"`      // Fraction of stake for worker fraud and DA unavailability
+	FalsePassPenalty  int           `mapstructure:"false_pass_penalty"` // Validator false pass, basis points, ≥500
 }
 
 // APIConfig defines API server settings
@@ -136,6 +143,7 @@ func DefaultConfig() *Config {
 			SampleSize:         1000,
 			RedundancyRate:     0.025, // 2.5%
 			MinConfidence:      0.999,
+			DetectableFraction: 0.01, // 1%
 			StratifiedSampling: true,
 			AdaptiveEscalation: true,
 			FraudWindowTime:    3600 * time.Second,
@@ -145,8 +153,11 @@ func DefaultConfig() *Config {
 			MaxQuota:             0.125, // 12.5%
 			ExplorationRate:      0.05,  // 5%
 			NewcomerBoost:        0.1,
+			NewcomerJobs:         20,
 			PerformanceWindow:    30, // days
+			PerformanceHalfLife:  7,  // days
 			AntiCollusionEnabled: true,
+			MaxPerOperator:       8, // 12.5% of K
 		},
 		PPC: PPCConfig{
 			TargetUtilization:  0.7,
@@ -164,6 +175,7 @@ func DefaultConfig() *Config {
 			AvailabilityWindow: 300 * time.Second,
 			ReplicationFactor:  3,
 			LiveAuditEnabled:   true,
+			DiskQuota:          51200, // 50 GB
 		},
 		VRF: VRFConfig{
 			DelayBlocks:   2,
@@ -193,8 +205,24 @@ func DefaultConfig() *Config {
 	}
 }
 
-// LoadConfig loads configuration from file and environment
+// LoadConfig loads configuration from file and environment and rejects
+// PoPC settings that cannot reach their own MinConfidence
 func LoadConfig(configPath string) (*Config, error) {
+	config, err := ReadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.PoPC.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid popc config: %w", err)
+	}
+
+	return config, nil
+}
+
+// ReadConfig loads configuration from file and environment without
+// validating it, e.g. to inspect a configuration LoadConfig rejects
+func ReadConfig(configPath string) (*Config, error) {
 	config := DefaultConfig()
 
 	if configPath != "" {
@@ -223,3 +251,64 @@ func LoadConfig(configPath string) (*Config, error) {
 
 	return config, nil
 }
+
+// Validate checks that the configuration is internally consistent. Loading
+// only checks the PoPC section; the rest is checked here or by the package
+// that uses it.
+func (c *Config) Validate() error {
+	if err := c.PoPC.Validate(); err != nil {
+		return fmt.Errorf("invalid popc config: %w", err)
+	}
+	if err := c.PPC.Validate(); err != nil {
+		return fmt.Errorf("invalid ppc config: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the PoPC parameters, including that SampleSize can reach
+// MinConfidence against DetectableFraction
+func (c PoPCConfig) Validate() error {
+	if c.SampleSize <= 0 {
+		return fmt.Errorf("sample_size must be positive, got %d", c.SampleSize)
+	}
+	if c.RedundancyRate < 0 || c.RedundancyRate > 1 {
+		return fmt.Errorf("redundancy_rate must be in [0, 1], got %v", c.RedundancyRate)
+	}
+	if c.MinConfidence <= 0 || c.MinConfidence >= 1 {
+		return fmt.Errorf("min_confidence must be in (0, 1), got %v", c.MinConfidence)
+	}
+	if c.DetectableFraction <= 0 || c.DetectableFraction > 1 {
+		return fmt.Errorf("detectable_fraction must be in (0, 1], got %v", c.DetectableFraction)
+	}
+
+	// Unbounded-output bound 1 - (1-f)^s; finite outputs only detect better
+	confidence := 1 - math.Pow(1-c.DetectableFraction, float64(c.SampleSize))
+	if confidence < c.MinConfidence {
+		return fmt.Errorf("sample_size %d detects a %v corrupted fraction with probability %.6f, below min_confidence %v",
+			c.SampleSize, c.DetectableFraction, confidence, c.MinConfidence)
+	}
+	return nil
+}
+
+// Validate checks the PPC targets, gains and price bounds
+func (c PPCConfig) Validate() error {
+	if c.TargetUtilization <= 0 || c.TargetUtilization > 1 {
+		return fmt.Errorf("target_utilization must be in (0, 1], got %v", c.TargetUtilization)
+	}
+	if c.TargetQueueTime <= 0 {
+		return fmt.Errorf("target_queue_time must be positive, got %v", c.TargetQueueTime)
+	}
+	if c.Alpha < 0 || c.Beta < 0 {
+		return fmt.Errorf("alpha and beta must not be negative, got %v and %v", c.Alpha, c.Beta)
+	}
+	if c.MinPrice <= 0 {
+		return fmt.Errorf("min_price must be positive, got %v", c.MinPrice)
+	}
+	if c.MaxPrice < c.MinPrice {
+		return fmt.Errorf("max_price %v is below min_price %v", c.MaxPrice, c.MinPrice)
+	}
+	if c.AdjustmentInterval <= 0 {
+		return fmt.Errorf("adjustment_interval must be positive, got %v", c.AdjustmentInterval)
+	}
+	return nil
+}
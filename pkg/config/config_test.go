@@ -34,6 +34,7 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 1000, cfg.PoPC.SampleSize)
 	assert.Equal(t, 0.025, cfg.PoPC.RedundancyRate)
 	assert.Equal(t, 0.999, cfg.PoPC.MinConfidence)
+	assert.Equal(t, 0.01, cfg.PoPC.DetectableFraction)
 	assert.True(t, cfg.PoPC.StratifiedSampling)
 	assert.True(t, cfg.PoPC.AdaptiveEscalation)
 	assert.Equal(t, 3600*time.Second, cfg.PoPC.FraudWindowTime)
@@ -43,7 +44,10 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 0.125, cfg.ASR.MaxQuota)
 	assert.Equal(t, 0.05, cfg.ASR.ExplorationRate)
 	assert.Equal(t, 0.1, cfg.ASR.NewcomerBoost)
+	assert.Equal(t, 20, cfg.ASR.NewcomerJobs)
+	assert.Equal(t, 8, cfg.ASR.MaxPerOperator)
 	assert.Equal(t, 30, cfg.ASR.PerformanceWindow)
+	assert.Equal(t, 7.0, cfg.ASR.PerformanceHalfLife)
 	assert.True(t, cfg.ASR.AntiCollusionEnabled)
 
 	// Test PPC config
@@ -62,6 +66,7 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 300*time.Second, cfg.DA.AvailabilityWindow)
 	assert.Equal(t, 3, cfg.DA.ReplicationFactor)
 	assert.True(t, cfg.DA.LiveAuditEnabled)
+	assert.Equal(t, 51200, cfg.DA.DiskQuota)
 
 	// Test VRF config
 	assert.Equal(t, 2, cfg.VRF.DelayBlocks)
@@ -289,6 +294,108 @@ func TestPoPCConfig_Defaults(t *testing.T) {
 	assert.Greater(t, cfg.PoPC.FraudWindowTime, time.Duration(0))
 }
 
+func TestPoPCConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*PoPCConfig)
+		valid  bool
+	}{
+		{"Defaults", func(c *PoPCConfig) {}, true},
+		{"Zero sample size", func(c *PoPCConfig) { c.SampleSize = 0 }, false},
+		{"Confidence of one", func(c *PoPCConfig) { c.MinConfidence = 1 }, false},
+		{"Negative redundancy", func(c *PoPCConfig) { c.RedundancyRate = -0.1 }, false},
+		{"Zero detectable fraction", func(c *PoPCConfig) { c.DetectableFraction = 0 }, false},
+		{"Sample too small for confidence", func(c *PoPCConfig) { c.SampleSize = 300 }, false},
+		{"Larger fraction needs fewer samples", func(c *PoPCConfig) {
+			c.SampleSize = 300
+			c.DetectableFraction = 0.05
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig().PoPC
+			tt.modify(&cfg)
+			if tt.valid {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.Error(t, cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestPPCConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*PPCConfig)
+		valid  bool
+	}{
+		{"Defaults", func(c *PPCConfig) {}, true},
+		{"Zero target utilization", func(c *PPCConfig) { c.TargetUtilization = 0 }, false},
+		{"Target utilization above one", func(c *PPCConfig) { c.TargetUtilization = 1.2 }, false},
+		{"Zero target queue time", func(c *PPCConfig) { c.TargetQueueTime = 0 }, false},
+		{"Negative alpha", func(c *PPCConfig) { c.Alpha = -0.1 }, false},
+		{"Zero min price", func(c *PPCConfig) { c.MinPrice = 0 }, false},
+		{"Max below min", func(c *PPCConfig) { c.MaxPrice = c.MinPrice / 2 }, false},
+		{"Fixed price", func(c *PPCConfig) { c.MaxPrice = c.MinPrice }, true},
+		{"Zero interval", func(c *PPCConfig) { c.AdjustmentInterval = 0 }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig().PPC
+			tt.modify(&cfg)
+			if tt.valid {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.Error(t, cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RejectsUnreachableConfidence(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+popc:
+  sample_size: 100
+  min_confidence: 0.999
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadConfig(configPath)
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+
+	// ReadConfig still loads it for inspection
+	cfg, err = ReadConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.PoPC.SampleSize)
+	assert.Error(t, cfg.PoPC.Validate())
+}
+
+func TestLoadConfig_DoesNotValidatePPC(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+ppc:
+  min_price: 0
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.Error(t, cfg.Validate())
+}
+
 func TestASRConfig_Defaults(t *testing.T) {
 	cfg := DefaultConfig()
 
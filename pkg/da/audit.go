@@ -0,0 +1,341 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/asr"
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/axionaxprotocol/axionax-core/pkg/slashing"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Domain tag for audit sample derivation
+const auditDomain = "axionax/da/audit/v1"
+
+// Audit defaults
+const (
+	DefaultAuditSamples    = 4 // Random shards requested per job per round
+	DefaultAuditsPerWindow = 5 // Rounds Run performs within one availability window
+	maxUnavailableReported = 8 // Shard indices listed in evidence detail
+	unavailableAfter       = 2 // Consecutive failed requests before a shard counts as unavailable
+)
+
+var (
+	// ErrRootMismatch is returned when a manifest does not match the job's committed root
+	ErrRootMismatch = errors.New("da: manifest does not match committed root")
+	// ErrAlreadyAudited is returned when a job is already being audited
+	ErrAlreadyAudited = errors.New("da: job already audited")
+	// ErrInvalidSamples is returned when the audit sample count is below 1
+	ErrInvalidSamples = errors.New("da: audit samples must be at least 1")
+)
+
+// ShardSource serves a worker's stored shards. *Store implements it.
+type ShardSource interface {
+	Shard(jobID string, i int) ([]byte, error)
+}
+
+// AuditResult is the outcome of requesting one shard
+type AuditResult struct {
+	JobID  string         `json:"job_id"`
+	Worker common.Address `json:"worker"`
+	Index  int            `json:"index"`
+	OK     bool           `json:"ok"`
+	Error  string         `json:"error,omitempty"`
+	At     time.Time      `json:"at"`
+}
+
+// AuditTally counts a worker's audit results
+type AuditTally struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// Reliability returns the fraction of audits passed, or 1 with no audits
+func (t AuditTally) Reliability() float64 {
+	if t.Passed+t.Failed == 0 {
+		return 1
+	}
+	return float64(t.Passed) / float64(t.Passed+t.Failed)
+}
+
+// auditedJob is a job whose shards are being audited
+type auditedJob struct {
+	manifest *Manifest
+	worker   common.Address
+	source   ShardSource
+	closesAt time.Time
+	failing  map[int]int // Consecutive failed requests of shards not served since
+}
+
+// Auditor samples shards of jobs inside DAConfig.AvailabilityWindow and
+// verifies them against the committed shard root. Every result is recorded
+// in the performance history, if set, from which asr.History.Refresh derives
+// each worker's DAReliability. A shard that failed and failed again when
+// re-requested, at the latest when the job's window closes, becomes DA
+// slashing evidence; a single transient failure does not.
+type Auditor struct {
+	mu      sync.Mutex
+	cfg     config.DAConfig
+	samples int
+	history *asr.History
+	jobs    map[string]*auditedJob
+	tallies map[common.Address]*AuditTally
+}
+
+// NewAuditor creates an auditor for the DA configuration
+func NewAuditor(cfg config.DAConfig) *Auditor {
+	return &Auditor{
+		cfg:     cfg,
+		samples: DefaultAuditSamples,
+		jobs:    make(map[string]*auditedJob),
+		tallies: make(map[common.Address]*AuditTally),
+	}
+}
+
+// SetSamples sets how many random shards are requested per job per round
+func (a *Auditor) SetSamples(n int) error {
+	if n < 1 {
+		return fmt.Errorf("%w: %d", ErrInvalidSamples, n)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.samples = n
+	return nil
+}
+
+// SetHistory sets the performance history that audit results are recorded in
+func (a *Auditor) SetHistory(h *asr.History) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.history = h
+}
+
+// Track starts auditing a committed job served by w from source. The
+// availability window runs from now. The manifest must carry the job's
+// committed output root and be valid, which means its data shard hashes
+// rebuild that root, so every data shard that passes an audit is part of
+// the committed output.
+func (a *Auditor) Track(job *types.Job, w *types.Worker, m *Manifest, source ShardSource, now time.Time) error {
+	if job.OutputRoot == (common.Hash{}) || m.OutputRoot != job.OutputRoot || m.JobID != job.ID {
+		return fmt.Errorf("%w: job %s", ErrRootMismatch, job.ID)
+	}
+	if err := m.Validate(); err != nil {
+		return fmt.Errorf("%w: job %s: %w", ErrRootMismatch, job.ID, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.jobs[job.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyAudited, job.ID)
+	}
+	a.jobs[job.ID] = &auditedJob{
+		manifest: m,
+		worker:   w.Address,
+		source:   source,
+		closesAt: now.Add(a.cfg.AvailabilityWindow),
+		failing:  make(map[int]int),
+	}
+	return nil
+}
+
+// Tally returns a worker's audit counts
+func (a *Auditor) Tally(addr common.Address) AuditTally {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if t, ok := a.tallies[addr]; ok {
+		return *t
+	}
+	return AuditTally{}
+}
+
+// Pending returns the IDs of jobs still being audited, sorted
+func (a *Auditor) Pending() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.sortedJobIDs()
+}
+
+// AuditRound requests shards from every job still inside its window: the
+// shards that failed before, plus random samples derived from the seed. It
+// does nothing when live auditing is disabled.
+func (a *Auditor) AuditRound(seed common.Hash, now time.Time) []AuditResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.cfg.LiveAuditEnabled {
+		return nil
+	}
+
+	var results []AuditResult
+	for _, id := range a.sortedJobIDs() {
+		job := a.jobs[id]
+		if !now.Before(job.closesAt) {
+			continue
+		}
+		for _, i := range a.auditIndices(job, seed) {
+			results = append(results, a.audit(job, i, now))
+		}
+	}
+	return results
+}
+
+// Expire stops auditing jobs whose window has closed at now. Shards that
+// failed only once are re-requested first. It returns slashing evidence for
+// each job with shards that failed on a re-request and were never served.
+func (a *Auditor) Expire(now time.Time) []slashing.Evidence {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var evidence []slashing.Evidence
+	for _, id := range a.sortedJobIDs() {
+		job := a.jobs[id]
+		if now.Before(job.closesAt) {
+			continue
+		}
+		delete(a.jobs, id)
+
+		var unavailable []int
+		for _, i := range sortedFailing(job) {
+			if job.failing[i] < unavailableAfter {
+				a.audit(job, i, now)
+			}
+			if job.failing[i] >= unavailableAfter {
+				unavailable = append(unavailable, i)
+			}
+		}
+		if len(unavailable) == 0 {
+			continue
+		}
+		evidence = append(evidence, slashing.Evidence{
+			Offence:  slashing.OffenceDAUnavailability,
+			Offender: job.worker,
+			JobID:    id,
+			Detail:   unavailableDetail(unavailable),
+		})
+	}
+	return evidence
+}
+
+// Run audits every AvailabilityWindow / DefaultAuditsPerWindow until ctx is
+// cancelled, passing each round's results and any evidence to report
+func (a *Auditor) Run(ctx context.Context, seed func(now time.Time) common.Hash, report func([]AuditResult, []slashing.Evidence)) error {
+	interval := a.cfg.AvailabilityWindow / DefaultAuditsPerWindow
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			results := a.AuditRound(seed(now), now)
+			evidence := a.Expire(now)
+			if report != nil && (len(results) > 0 || len(evidence) > 0) {
+				report(results, evidence)
+			}
+		}
+	}
+}
+
+// audit requests and verifies one shard, updating the job, the worker's
+// tally and the history
+func (a *Auditor) audit(job *auditedJob, i int, now time.Time) AuditResult {
+	m := job.manifest
+	res := AuditResult{JobID: m.JobID, Worker: job.worker, Index: i, At: now}
+
+	shard, err := job.source.Shard(m.JobID, i)
+	if err == nil {
+		err = m.VerifyShard(i, shard)
+	}
+	if err != nil {
+		res.Error = err.Error()
+		job.failing[i]++
+	} else {
+		res.OK = true
+		delete(job.failing, i)
+	}
+
+	t, ok := a.tallies[job.worker]
+	if !ok {
+		t = &AuditTally{}
+		a.tallies[job.worker] = t
+	}
+	if res.OK {
+		t.Passed++
+	} else {
+		t.Failed++
+	}
+	if a.history != nil {
+		a.history.RecordAudit(asr.AuditOutcome{JobID: m.JobID, Worker: job.worker, Shard: i, Passed: res.OK, At: now})
+	}
+	return res
+}
+
+// auditIndices returns the failing shards followed by up to samples random
+// other shards, all distinct and in ascending order within each group
+func (a *Auditor) auditIndices(job *auditedJob, seed common.Hash) []int {
+	n := job.manifest.NumShards()
+	indices := sortedFailing(job)
+
+	k := a.samples
+	if k > n-len(indices) {
+		k = n - len(indices)
+	}
+	stream := randomness.NewStream(randomness.DeriveSeed(auditDomain, seed.Bytes(), []byte(job.manifest.JobID)))
+	chosen := make(map[int]bool, k)
+	var sampled []int
+	for len(sampled) < k {
+		i := stream.Intn(n)
+		if job.failing[i] > 0 || chosen[i] {
+			continue
+		}
+		chosen[i] = true
+		sampled = append(sampled, i)
+	}
+	sort.Ints(sampled)
+	return append(indices, sampled...)
+}
+
+// sortedJobIDs returns tracked job IDs in order so rounds are reproducible
+func (a *Auditor) sortedJobIDs() []string {
+	ids := make([]string, 0, len(a.jobs))
+	for id := range a.jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// sortedFailing returns a job's failing shards in ascending order
+func sortedFailing(job *auditedJob) []int {
+	indices := make([]int, 0, len(job.failing))
+	for i := range job.failing {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// unavailableDetail describes the shards that were never served
+func unavailableDetail(indices []int) string {
+	if len(indices) > maxUnavailableReported {
+		return fmt.Sprintf("%d shards unavailable at window close, first %v", len(indices), indices[:maxUnavailableReported])
+	}
+	return fmt.Sprintf("%d shards unavailable at window close: %v", len(indices), indices)
+}
@@ -0,0 +1,245 @@
+package da
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/asr"
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/slashing"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditFixture stores an output and returns a committed job, its worker
+// and manifest
+func auditFixture(t *testing.T, s *Store, jobID string) (*types.Job, *types.Worker, *Manifest) {
+	m, err := s.Put(jobID, testOutput(8*1024))
+	require.NoError(t, err)
+
+	w := &types.Worker{
+		Address: common.BigToAddress(big.NewInt(int64(len(jobID)))),
+		Status:  types.WorkerStatusActive,
+	}
+	job := &types.Job{ID: jobID, Status: types.JobStatusCommitted, Worker: w.Address, OutputRoot: m.OutputRoot}
+	return job, w, m
+}
+
+func auditConfig() config.DAConfig {
+	cfg := config.DefaultConfig().DA
+	cfg.AvailabilityWindow = 10 * time.Minute
+	return cfg
+}
+
+func TestAuditor_Track(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	a := NewAuditor(auditConfig())
+	now := time.Unix(1_700_000_000, 0)
+
+	wrong := *job
+	wrong.OutputRoot = common.HexToHash("0xbad")
+	assert.ErrorIs(t, a.Track(&wrong, w, m, s, now), ErrRootMismatch)
+
+	tampered := *m
+	tampered.ShardHashes = append([]common.Hash(nil), m.ShardHashes...)
+	tampered.ShardHashes[0] = common.HexToHash("0xbad")
+	assert.ErrorIs(t, a.Track(job, w, &tampered, s, now), ErrRootMismatch)
+
+	require.NoError(t, a.Track(job, w, m, s, now))
+	assert.ErrorIs(t, a.Track(job, w, m, s, now), ErrAlreadyAudited)
+	assert.Equal(t, []string{"job-1"}, a.Pending())
+}
+
+func TestAuditor_TrackRejectsForgedShards(t *testing.T) {
+	s := newTestStore(t)
+	job, w, _ := auditFixture(t, s, "job-1")
+	a := NewAuditor(auditConfig())
+	now := time.Unix(1_700_000_000, 0)
+
+	// A worker stores garbage and writes a self-consistent manifest for it
+	// that copies the committed output root
+	garbage := testOutput(8 * 1024)
+	for i := range garbage {
+		garbage[i] ^= 0xff
+	}
+	forged, shards, err := Encode("job-1", garbage, 1024, auditConfig().ErasureCodingRate)
+	require.NoError(t, err)
+	forged.OutputRoot = job.OutputRoot
+	for i, shard := range shards {
+		require.NoError(t, forged.VerifyShard(i, shard), "shards match the forged hashes")
+	}
+	assert.ErrorIs(t, forged.Validate(), ErrInvalidManifest)
+
+	assert.ErrorIs(t, a.Track(job, w, forged, s, now), ErrRootMismatch)
+	assert.Empty(t, a.Pending())
+}
+
+func TestAuditor_HonestWorker(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	a := NewAuditor(auditConfig())
+	history := asr.NewHistory(config.DefaultConfig().ASR)
+	a.SetHistory(history)
+	w.Performance.DAReliability = 0.5
+	now := time.Unix(1_700_000_000, 0)
+	require.NoError(t, a.Track(job, w, m, s, now))
+
+	results := a.AuditRound(common.HexToHash("0x1"), now.Add(time.Minute))
+	require.Len(t, results, DefaultAuditSamples)
+	for _, r := range results {
+		assert.True(t, r.OK, r.Error)
+	}
+	assert.Equal(t, AuditTally{Passed: DefaultAuditSamples}, a.Tally(w.Address))
+
+	// The auditor only records; the history refresh updates the worker
+	assert.Equal(t, 0.5, w.Performance.DAReliability)
+	history.Refresh(w, now.Add(time.Minute))
+	assert.Equal(t, 1.0, w.Performance.DAReliability)
+
+	// Same seed, same samples
+	again := a.AuditRound(common.HexToHash("0x1"), now.Add(2*time.Minute))
+	for i := range results {
+		assert.Equal(t, results[i].Index, again[i].Index)
+	}
+
+	assert.Empty(t, a.Expire(now.Add(9*time.Minute)))
+	assert.Empty(t, a.Expire(now.Add(10*time.Minute)))
+	assert.Empty(t, a.Pending())
+}
+
+func TestAuditor_UnavailableShardsBecomeEvidence(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	a := NewAuditor(auditConfig())
+	history := asr.NewHistory(config.DefaultConfig().ASR)
+	a.SetHistory(history)
+	require.NoError(t, a.SetSamples(m.NumShards()))
+	now := time.Unix(1_700_000_000, 0)
+	require.NoError(t, a.Track(job, w, m, s, now))
+
+	require.NoError(t, s.DeleteShard("job-1", 3))
+	results := a.AuditRound(common.HexToHash("0x1"), now.Add(time.Minute))
+	require.Len(t, results, m.NumShards())
+	assert.False(t, results[3].OK)
+	stats, ok := history.Stats(w.Address, now.Add(time.Minute))
+	require.True(t, ok)
+	assert.Less(t, stats.DAReliability, 1.0)
+
+	// After the window no more audits run; the shard is re-requested at
+	// close, fails again and is reported
+	assert.Empty(t, a.AuditRound(common.HexToHash("0x2"), now.Add(10*time.Minute)))
+	evidence := a.Expire(now.Add(10 * time.Minute))
+	require.Len(t, evidence, 1)
+	assert.Equal(t, slashing.OffenceDAUnavailability, evidence[0].Offence)
+	assert.Equal(t, w.Address, evidence[0].Offender)
+	assert.Equal(t, "job-1", evidence[0].JobID)
+	assert.Contains(t, evidence[0].Detail, "[3]")
+	assert.Equal(t, 2, a.Tally(w.Address).Failed)
+}
+
+func TestAuditor_TransientFailureIsRetried(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	a := NewAuditor(auditConfig())
+	require.NoError(t, a.SetSamples(m.NumShards()))
+	now := time.Unix(1_700_000_000, 0)
+	require.NoError(t, a.Track(job, w, m, s, now))
+
+	// One failed request in the last round before the window closes
+	shard, err := s.Shard("job-1", 2)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteShard("job-1", 2))
+	results := a.AuditRound(common.HexToHash("0x1"), now.Add(9*time.Minute))
+	assert.False(t, results[2].OK)
+	require.NoError(t, s.PutShard(m, 2, shard))
+
+	assert.Empty(t, a.Expire(now.Add(10*time.Minute)))
+	assert.Equal(t, AuditTally{Passed: m.NumShards(), Failed: 1}, a.Tally(w.Address))
+}
+
+func TestAuditor_RecoveredShardsAreForgiven(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	a := NewAuditor(auditConfig())
+	now := time.Unix(1_700_000_000, 0)
+	require.NoError(t, a.Track(job, w, m, s, now))
+
+	shard, err := s.Shard("job-1", 5)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteShard("job-1", 5))
+
+	// Force a failure on shard 5, fail its re-request, then restore it
+	require.NoError(t, a.SetSamples(m.NumShards()))
+	a.AuditRound(common.HexToHash("0x1"), now.Add(time.Minute))
+	require.NoError(t, a.SetSamples(1))
+	results := a.AuditRound(common.HexToHash("0x2"), now.Add(2*time.Minute))
+	require.Len(t, results, 2)
+	assert.Equal(t, 5, results[0].Index, "failing shards are re-requested first")
+	assert.False(t, results[0].OK)
+	require.NoError(t, s.PutShard(m, 5, shard))
+
+	results = a.AuditRound(common.HexToHash("0x3"), now.Add(3*time.Minute))
+	require.Len(t, results, 2)
+	assert.Equal(t, 5, results[0].Index)
+	assert.True(t, results[0].OK)
+
+	assert.Empty(t, a.Expire(now.Add(10*time.Minute)))
+	assert.Equal(t, 2, a.Tally(w.Address).Failed)
+}
+
+func TestAuditor_SetSamples(t *testing.T) {
+	a := NewAuditor(auditConfig())
+	assert.ErrorIs(t, a.SetSamples(0), ErrInvalidSamples)
+	assert.ErrorIs(t, a.SetSamples(-1), ErrInvalidSamples)
+	assert.NoError(t, a.SetSamples(1))
+}
+
+func TestAuditor_Disabled(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	cfg := auditConfig()
+	cfg.LiveAuditEnabled = false
+	a := NewAuditor(cfg)
+	now := time.Unix(1_700_000_000, 0)
+	require.NoError(t, a.Track(job, w, m, s, now))
+
+	assert.Nil(t, a.AuditRound(common.HexToHash("0x1"), now))
+	assert.Equal(t, AuditTally{}, a.Tally(w.Address))
+}
+
+func TestAuditor_Run(t *testing.T) {
+	s := newTestStore(t)
+	job, w, m := auditFixture(t, s, "job-1")
+	cfg := auditConfig()
+	cfg.AvailabilityWindow = 50 * time.Millisecond
+	a := NewAuditor(cfg)
+	require.NoError(t, a.Track(job, w, m, s, time.Now()))
+	require.NoError(t, s.DeleteShard("job-1", 0))
+	require.NoError(t, a.SetSamples(m.NumShards()))
+
+	var (
+		mu       sync.Mutex
+		evidence []slashing.Evidence
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	err := a.Run(ctx, func(now time.Time) common.Hash {
+		return common.BigToHash(big.NewInt(now.UnixNano()))
+	}, func(_ []AuditResult, ev []slashing.Evidence) {
+		mu.Lock()
+		defer mu.Unlock()
+		evidence = append(evidence, ev...)
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, evidence, 1)
+	assert.Equal(t, "job-1", evidence[0].JobID)
+}
@@ -0,0 +1,393 @@
+// Package da implements erasure-coded data availability for job outputs
+package da
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/reedsolomon"
+)
+
+// MaxStripeShards is the most data plus parity shards one Reed-Solomon
+// stripe can hold over GF(2^8). Larger outputs are split across stripes.
+const MaxStripeShards = 256
+
+var (
+	// ErrEmptyOutput is returned when encoding an empty output
+	ErrEmptyOutput = errors.New("da: output is empty")
+	// ErrInvalidRate is returned when the erasure coding rate is below 1 or
+	// too high for even one data shard to fit a stripe
+	ErrInvalidRate = errors.New("da: erasure coding rate must be between 1 and 256")
+	// ErrInvalidChunkSize is returned when the chunk size is not positive
+	ErrInvalidChunkSize = errors.New("da: chunk size must be positive")
+	// ErrShardMismatch is returned when shards do not match their manifest
+	ErrShardMismatch = errors.New("da: shards do not match manifest")
+	// ErrTooFewShards is returned when a stripe has too few intact shards to reconstruct
+	ErrTooFewShards = errors.New("da: not enough shards to reconstruct")
+	// ErrInvalidManifest is returned for a manifest whose layout is inconsistent
+	ErrInvalidManifest = errors.New("da: invalid manifest")
+)
+
+// Stripe describes one Reed-Solomon stripe of a manifest
+type Stripe struct {
+	DataShards   int `json:"data_shards"`
+	ParityShards int `json:"parity_shards"`
+	ShardSize    int `json:"shard_size"` // in bytes
+}
+
+// Manifest describes how an output was erasure coded. Shards are numbered
+// across stripes in order, data shards before parity within each stripe.
+// Data shard k holds output chunk k, zero padded to its stripe's shard size,
+// and its hash is taken over the chunk without the padding, so the data
+// shard hashes in order are the leaves of OutputRoot.
+type Manifest struct {
+	JobID       string        `json:"job_id"`
+	OutputRoot  common.Hash   `json:"output_root"` // Merkle root of the output chunks, as committed by the job
+	ShardRoot   common.Hash   `json:"shard_root"`  // Merkle root of ShardHashes
+	Size        int           `json:"size"`        // Output length in bytes
+	ChunkSize   int           `json:"chunk_size"`  // in bytes
+	Stripes     []Stripe      `json:"stripes"`
+	ShardHashes []common.Hash `json:"shard_hashes"` // merkle.HashLeaf of every parity shard and every data shard's chunk
+}
+
+// NumShards returns the total number of shards
+func (m *Manifest) NumShards() int {
+	n := 0
+	for _, s := range m.Stripes {
+		n += s.DataShards + s.ParityShards
+	}
+	return n
+}
+
+// Validate checks that the manifest's layout is consistent, so that it can
+// be used to size and reconstruct an output, and that its shard hashes
+// match both ShardRoot and, through the data shards, OutputRoot
+func (m *Manifest) Validate() error {
+	if m.Size <= 0 {
+		return fmt.Errorf("%w: size %d", ErrInvalidManifest, m.Size)
+	}
+	if m.ChunkSize <= 0 {
+		return fmt.Errorf("%w: chunk size %d", ErrInvalidManifest, m.ChunkSize)
+	}
+	if len(m.Stripes) == 0 {
+		return fmt.Errorf("%w: no stripes", ErrInvalidManifest)
+	}
+
+	chunks := (m.Size + m.ChunkSize - 1) / m.ChunkSize
+	data := 0
+	for s, st := range m.Stripes {
+		if st.DataShards < 1 || st.ParityShards < 0 || st.DataShards+st.ParityShards > MaxStripeShards {
+			return fmt.Errorf("%w: stripe %d has %d data and %d parity shards", ErrInvalidManifest, s, st.DataShards, st.ParityShards)
+		}
+		if st.ShardSize <= 0 || st.ShardSize > m.ChunkSize {
+			return fmt.Errorf("%w: stripe %d shard size %d", ErrInvalidManifest, s, st.ShardSize)
+		}
+		if data+st.DataShards > chunks {
+			return fmt.Errorf("%w: more data shards than the %d chunks of a %d byte output", ErrInvalidManifest, chunks, m.Size)
+		}
+		// Every chunk of the stripe, and so its largest first one, must fit a shard
+		if n := m.chunkLen(data); n > st.ShardSize {
+			return fmt.Errorf("%w: stripe %d shard size %d is below its %d byte chunks", ErrInvalidManifest, s, st.ShardSize, n)
+		}
+		data += st.DataShards
+	}
+	if data != chunks {
+		return fmt.Errorf("%w: %d data shards for %d chunks", ErrInvalidManifest, data, chunks)
+	}
+	if len(m.ShardHashes) != m.NumShards() {
+		return fmt.Errorf("%w: %d shard hashes for %d shards", ErrInvalidManifest, len(m.ShardHashes), m.NumShards())
+	}
+	tree, err := merkle.NewTreeFromLeaves(m.ShardHashes)
+	if err != nil || tree.Root() != m.ShardRoot {
+		return fmt.Errorf("%w: shard hashes do not match shard root", ErrInvalidManifest)
+	}
+	if root, err := m.DataRoot(); err != nil || root != m.OutputRoot {
+		return fmt.Errorf("%w: data shard hashes do not match output root", ErrInvalidManifest)
+	}
+	return nil
+}
+
+// DataRoot returns the Merkle root of the data shard hashes in order. For a
+// manifest of an intact output it equals OutputRoot.
+func (m *Manifest) DataRoot() (common.Hash, error) {
+	var leaves []common.Hash
+	offset := 0
+	for _, st := range m.Stripes {
+		if offset+st.DataShards > len(m.ShardHashes) {
+			return common.Hash{}, fmt.Errorf("%w: %d shard hashes", ErrInvalidManifest, len(m.ShardHashes))
+		}
+		leaves = append(leaves, m.ShardHashes[offset:offset+st.DataShards]...)
+		offset += st.DataShards + st.ParityShards
+	}
+	tree, err := merkle.NewTreeFromLeaves(leaves)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tree.Root(), nil
+}
+
+// Locate returns the stripe holding shard i and the shard's offset in it
+func (m *Manifest) Locate(i int) (stripe, offset int, err error) {
+	if i >= 0 {
+		for s, st := range m.Stripes {
+			n := st.DataShards + st.ParityShards
+			if i < n {
+				return s, i, nil
+			}
+			i -= n
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: shard %d", ErrShardMismatch, i)
+}
+
+// VerifyShard checks a shard against the manifest's shard hashes. A data
+// shard must be its chunk followed by zero padding.
+func (m *Manifest) VerifyShard(i int, shard []byte) error {
+	if i < 0 || i >= len(m.ShardHashes) {
+		return fmt.Errorf("%w: shard %d out of range", ErrShardMismatch, i)
+	}
+
+	content := shard
+	if chunk, ok := m.dataChunk(i); ok {
+		n := m.chunkLen(chunk)
+		if n > len(shard) {
+			return fmt.Errorf("%w: shard %d is %d bytes, chunk is %d", ErrShardMismatch, i, len(shard), n)
+		}
+		for _, b := range shard[n:] {
+			if b != 0 {
+				return fmt.Errorf("%w: shard %d padding", ErrShardMismatch, i)
+			}
+		}
+		content = shard[:n]
+	}
+	if merkle.HashLeaf(content) != m.ShardHashes[i] {
+		return fmt.Errorf("%w: shard %d hash", ErrShardMismatch, i)
+	}
+	return nil
+}
+
+// dataChunk returns the output chunk a shard holds, or false for a parity
+// shard
+func (m *Manifest) dataChunk(i int) (int, bool) {
+	chunk := 0
+	for _, st := range m.Stripes {
+		n := st.DataShards + st.ParityShards
+		if i < n {
+			if i < st.DataShards {
+				return chunk + i, true
+			}
+			return 0, false
+		}
+		i -= n
+		chunk += st.DataShards
+	}
+	return 0, false
+}
+
+// chunkLen returns the length in bytes of output chunk k
+func (m *Manifest) chunkLen(k int) int {
+	n := m.Size - k*m.ChunkSize
+	if n > m.ChunkSize {
+		return m.ChunkSize
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// ShardProof returns a proof that shard i is included under ShardRoot
+func (m *Manifest) ShardProof(i int) (*merkle.Proof, error) {
+	tree, err := merkle.NewTreeFromLeaves(m.ShardHashes)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Proof(i)
+}
+
+// Encode splits data into chunkSize-byte chunks and Reed-Solomon encodes
+// them, adding parity so that the shard count is about rate times the chunk
+// count. It returns the manifest and the shards in manifest order.
+func Encode(jobID string, data []byte, chunkSize int, rate float64) (*Manifest, [][]byte, error) {
+	if len(data) == 0 {
+		return nil, nil, ErrEmptyOutput
+	}
+	if chunkSize <= 0 {
+		return nil, nil, fmt.Errorf("%w: %d", ErrInvalidChunkSize, chunkSize)
+	}
+	if !validRate(rate) {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRate, rate)
+	}
+
+	chunks, err := merkle.SplitChunks(data, chunkSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputTree, err := merkle.NewTree(chunks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &Manifest{
+		JobID:      jobID,
+		OutputRoot: outputTree.Root(),
+		Size:       len(data),
+		ChunkSize:  chunkSize,
+	}
+
+	var shards [][]byte
+	perStripe := stripeDataShards(rate)
+	for start := 0; start < len(chunks); start += perStripe {
+		end := minInt(start+perStripe, len(chunks))
+		stripe, stripeShards, err := encodeStripe(chunks[start:end], rate)
+		if err != nil {
+			return nil, nil, err
+		}
+		m.Stripes = append(m.Stripes, stripe)
+		for j, s := range stripeShards {
+			// Data shards are hashed without padding, as output chunks
+			if j < stripe.DataShards {
+				s = chunks[start+j]
+			}
+			m.ShardHashes = append(m.ShardHashes, merkle.HashLeaf(s))
+		}
+		shards = append(shards, stripeShards...)
+	}
+
+	shardTree, err := merkle.NewTreeFromLeaves(m.ShardHashes)
+	if err != nil {
+		return nil, nil, err
+	}
+	m.ShardRoot = shardTree.Root()
+	return m, shards, nil
+}
+
+// Reconstruct rebuilds the output from any sufficient subset of shards.
+// shards is indexed like the manifest; nil entries are missing, and shards
+// that fail verification are treated as missing. Each stripe needs at least
+// its data shard count of intact shards.
+func Reconstruct(m *Manifest, shards [][]byte) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if len(shards) != m.NumShards() {
+		return nil, fmt.Errorf("%w: expected %d shards, got %d", ErrShardMismatch, m.NumShards(), len(shards))
+	}
+
+	data := make([]byte, 0, m.Size)
+	offset := 0
+	for s, st := range m.Stripes {
+		n := st.DataShards + st.ParityShards
+		stripe := make([][]byte, n)
+		present := 0
+		for j := 0; j < n; j++ {
+			shard := shards[offset+j]
+			if shard != nil && len(shard) == st.ShardSize && m.VerifyShard(offset+j, shard) == nil {
+				stripe[j] = shard
+				present++
+			}
+		}
+		if present < st.DataShards {
+			return nil, fmt.Errorf("%w: stripe %d has %d of %d", ErrTooFewShards, s, present, st.DataShards)
+		}
+
+		if present < n && st.ParityShards > 0 {
+			enc, err := reedsolomon.New(st.DataShards, st.ParityShards)
+			if err != nil {
+				return nil, err
+			}
+			if err := enc.ReconstructData(stripe); err != nil {
+				return nil, fmt.Errorf("stripe %d: %w", s, err)
+			}
+		}
+		for j := 0; j < st.DataShards; j++ {
+			data = append(data, stripe[j]...)
+		}
+		offset += n
+	}
+
+	if len(data) < m.Size {
+		return nil, fmt.Errorf("%w: reconstructed %d of %d bytes", ErrShardMismatch, len(data), m.Size)
+	}
+	data = data[:m.Size]
+
+	chunks, err := merkle.SplitChunks(data, m.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := merkle.NewTree(chunks)
+	if err != nil {
+		return nil, err
+	}
+	if tree.Root() != m.OutputRoot {
+		return nil, fmt.Errorf("%w: output root", ErrShardMismatch)
+	}
+	return data, nil
+}
+
+// encodeStripe pads a stripe's chunks to a common size and appends parity
+func encodeStripe(chunks [][]byte, rate float64) (Stripe, [][]byte, error) {
+	st := Stripe{
+		DataShards:   len(chunks),
+		ParityShards: parityShards(len(chunks), rate),
+	}
+	for _, c := range chunks {
+		if len(c) > st.ShardSize {
+			st.ShardSize = len(c)
+		}
+	}
+
+	shards := make([][]byte, st.DataShards+st.ParityShards)
+	for i := range shards {
+		shards[i] = make([]byte, st.ShardSize)
+		if i < len(chunks) {
+			copy(shards[i], chunks[i])
+		}
+	}
+	if st.ParityShards == 0 {
+		return st, shards, nil
+	}
+
+	enc, err := reedsolomon.New(st.DataShards, st.ParityShards)
+	if err != nil {
+		return Stripe{}, nil, err
+	}
+	if err := enc.Encode(shards); err != nil {
+		return Stripe{}, nil, err
+	}
+	return st, shards, nil
+}
+
+// validRate reports whether rate is finite, at least 1 and low enough for a
+// single data shard and its parity to fit in one stripe
+func validRate(rate float64) bool {
+	if rate < 1 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return false
+	}
+	return 1+parityShards(1, rate) <= MaxStripeShards
+}
+
+// parityShards returns the parity needed to bring k data shards up to rate
+func parityShards(k int, rate float64) int {
+	return int(math.Ceil(float64(k)*(rate-1) - 1e-9))
+}
+
+// stripeDataShards returns the most data shards per stripe that keep data
+// plus parity within MaxStripeShards
+func stripeDataShards(rate float64) int {
+	k := MaxStripeShards
+	for k > 1 && k+parityShards(k, rate) > MaxStripeShards {
+		k--
+	}
+	return k
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
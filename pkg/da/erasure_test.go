@@ -0,0 +1,195 @@
+package da
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOutput returns n deterministic bytes
+func testOutput(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i*7 + i/251)
+	}
+	return data
+}
+
+func TestEncode_Layout(t *testing.T) {
+	data := testOutput(10*1024 + 100)
+	m, shards, err := Encode("job-1", data, 1024, 1.5)
+	require.NoError(t, err)
+
+	// 11 chunks, 6 parity shards
+	require.Len(t, m.Stripes, 1)
+	assert.Equal(t, Stripe{DataShards: 11, ParityShards: 6, ShardSize: 1024}, m.Stripes[0])
+	assert.Equal(t, 17, m.NumShards())
+	assert.Len(t, shards, 17)
+	assert.Equal(t, len(data), m.Size)
+
+	// The output root matches a direct commitment to the same chunks
+	chunks, err := merkle.SplitChunks(data, 1024)
+	require.NoError(t, err)
+	tree, err := merkle.NewTree(chunks)
+	require.NoError(t, err)
+	assert.Equal(t, tree.Root(), m.OutputRoot)
+
+	// The data shard hashes are the output's leaves, padding excluded
+	root, err := m.DataRoot()
+	require.NoError(t, err)
+	assert.Equal(t, m.OutputRoot, root)
+
+	// Every shard is provable under the shard root
+	for i, s := range shards {
+		require.NoError(t, m.VerifyShard(i, s))
+		proof, err := m.ShardProof(i)
+		require.NoError(t, err)
+		assert.NoError(t, merkle.VerifyLeaf(m.ShardRoot, m.ShardHashes[i], proof))
+	}
+
+	// The last data shard is padded; non-zero padding is rejected
+	last := append([]byte(nil), shards[10]...)
+	last[len(last)-1] = 1
+	assert.ErrorIs(t, m.VerifyShard(10, last), ErrShardMismatch)
+	assert.ErrorIs(t, m.VerifyShard(10, shards[10][:50]), ErrShardMismatch)
+}
+
+func TestEncode_Errors(t *testing.T) {
+	_, _, err := Encode("job", nil, 1024, 1.5)
+	assert.ErrorIs(t, err, ErrEmptyOutput)
+	_, _, err = Encode("job", []byte("x"), 0, 1.5)
+	assert.ErrorIs(t, err, ErrInvalidChunkSize)
+	for _, rate := range []float64{0.5, 256.5, 1000} {
+		_, _, err = Encode("job", []byte("x"), 1024, rate)
+		assert.ErrorIs(t, err, ErrInvalidRate, "rate %v", rate)
+	}
+	_, _, err = Encode("job", []byte("x"), 1024, MaxStripeShards)
+	assert.NoError(t, err)
+}
+
+func TestManifest_Validate(t *testing.T) {
+	valid := func(t *testing.T) *Manifest {
+		m, _, err := Encode("job-1", testOutput(3000), 1024, 1.5)
+		require.NoError(t, err)
+		require.NoError(t, m.Validate())
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(m *Manifest)
+	}{
+		{"negative size", func(m *Manifest) { m.Size = -1 }},
+		{"size beyond stripes", func(m *Manifest) { m.Size = 1 << 40 }},
+		{"zero chunk size", func(m *Manifest) { m.ChunkSize = 0 }},
+		{"no stripes", func(m *Manifest) { m.Stripes = nil }},
+		{"no data shards", func(m *Manifest) { m.Stripes[0].DataShards = 0 }},
+		{"negative parity", func(m *Manifest) { m.Stripes[0].ParityShards = -1 }},
+		{"too many shards", func(m *Manifest) { m.Stripes[0].ParityShards = MaxStripeShards }},
+		{"zero shard size", func(m *Manifest) { m.Stripes[0].ShardSize = 0 }},
+		{"shard hash count", func(m *Manifest) { m.ShardHashes = m.ShardHashes[1:] }},
+		{"shard root", func(m *Manifest) { m.ShardHashes[0] = common.HexToHash("0xbad") }},
+		{"extra data shard", func(m *Manifest) { m.Stripes[0].DataShards++; m.Stripes[0].ParityShards-- }},
+		{"shard smaller than chunk", func(m *Manifest) { m.Stripes[0].ShardSize = 512 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid(t)
+			tt.mutate(m)
+			assert.ErrorIs(t, m.Validate(), ErrInvalidManifest)
+			_, err := Reconstruct(m, make([][]byte, len(m.ShardHashes)))
+			assert.ErrorIs(t, err, ErrInvalidManifest)
+		})
+	}
+}
+
+func TestEncode_SplitsLargeOutputsIntoStripes(t *testing.T) {
+	data := testOutput(400 * 16)
+	m, shards, err := Encode("job-1", data, 16, 1.5)
+	require.NoError(t, err)
+
+	require.Len(t, m.Stripes, 3)
+	for _, st := range m.Stripes {
+		assert.LessOrEqual(t, st.DataShards+st.ParityShards, MaxStripeShards)
+	}
+	assert.Equal(t, 400, m.Stripes[0].DataShards+m.Stripes[1].DataShards+m.Stripes[2].DataShards)
+
+	// Drop a third of every stripe and still recover
+	for i := range shards {
+		if i%3 == 0 {
+			shards[i] = nil
+		}
+	}
+	got, err := Reconstruct(m, shards)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, got))
+}
+
+func TestReconstruct(t *testing.T) {
+	data := testOutput(8*512 + 3)
+	m, shards, err := Encode("job-1", data, 512, 1.5)
+	require.NoError(t, err)
+	n, k := m.NumShards(), m.Stripes[0].DataShards
+
+	tests := []struct {
+		name    string
+		damage  func(s [][]byte)
+		wantErr error
+	}{
+		{"all present", func(s [][]byte) {}, nil},
+		{"data shards missing", func(s [][]byte) {
+			for i := 0; i < n-k; i++ {
+				s[i] = nil
+			}
+		}, nil},
+		{"corrupted shards are dropped", func(s [][]byte) {
+			s[0] = append([]byte(nil), s[0]...)
+			s[0][0] ^= 0xff
+			s[1] = s[1][:10]
+		}, nil},
+		{"too many missing", func(s [][]byte) {
+			for i := 0; i <= n-k; i++ {
+				s[i] = nil
+			}
+		}, ErrTooFewShards},
+		{"wrong shard count", func(s [][]byte) {}, ErrShardMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			damaged := make([][]byte, len(shards))
+			copy(damaged, shards)
+			tt.damage(damaged)
+			if tt.name == "wrong shard count" {
+				damaged = damaged[1:]
+			}
+
+			got, err := Reconstruct(m, damaged)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(data, got))
+		})
+	}
+}
+
+func TestEncode_RateOne(t *testing.T) {
+	data := testOutput(3000)
+	m, shards, err := Encode("job-1", data, 1024, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, m.Stripes[0].ParityShards)
+
+	got, err := Reconstruct(m, shards)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	shards[0] = nil
+	_, err = Reconstruct(m, shards)
+	assert.ErrorIs(t, err, ErrTooFewShards)
+}
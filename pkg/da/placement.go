@@ -0,0 +1,329 @@
+package da
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Domain tag for placement seed derivation
+const placementDomain = "axionax/da/placement/v1"
+
+var (
+	// ErrInsufficientHolders is returned when fewer active holders exist than ReplicationFactor
+	ErrInsufficientHolders = errors.New("da: not enough active holders")
+	// ErrAlreadyPlaced is returned when a job's shards have already been placed
+	ErrAlreadyPlaced = errors.New("da: job already placed")
+	// ErrUnknownPlacement is returned for a job with no placement
+	ErrUnknownPlacement = errors.New("da: no placement for job")
+)
+
+// Placement records which holders keep each shard of a job
+type Placement struct {
+	JobID   string             `json:"job_id"`
+	Holders [][]common.Address `json:"holders"` // Indexed by shard
+}
+
+// Deficit is a shard with fewer live holders than ReplicationFactor
+type Deficit struct {
+	JobID   string           `json:"job_id"`
+	Shard   int              `json:"shard"`
+	Live    []common.Address `json:"live"`    // Holders still active
+	Missing int              `json:"missing"` // Replicas needed to recover
+}
+
+// Transfer is a repair action: copy a shard from a live holder to a new one.
+// With no live holder left the shard must be rebuilt from the job's other
+// shards by erasure decoding.
+type Transfer struct {
+	JobID   string         `json:"job_id"`
+	Shard   int            `json:"shard"`
+	From    common.Address `json:"from,omitempty"`
+	To      common.Address `json:"to"`
+	Rebuild bool           `json:"rebuild"`
+}
+
+// Placer assigns shards to DAConfig.ReplicationFactor distinct holders,
+// spreading each shard's replicas across ASNs and regions and balancing
+// the number of shards each holder keeps
+type Placer struct {
+	mu          sync.Mutex
+	replication int
+	placements  map[string]*Placement
+	load        map[common.Address]int
+}
+
+// NewPlacer creates a placer using DAConfig.ReplicationFactor
+func NewPlacer(cfg config.DAConfig) *Placer {
+	replication := cfg.ReplicationFactor
+	if replication < 1 {
+		replication = 1
+	}
+	return &Placer{
+		replication: replication,
+		placements:  make(map[string]*Placement),
+		load:        make(map[common.Address]int),
+	}
+}
+
+// Place chooses holders for every shard of a manifest from the active
+// workers. Ties between equally loaded holders are broken by the seed.
+func (p *Placer) Place(m *Manifest, workers []*types.Worker, seed common.Hash) (*Placement, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.placements[m.JobID]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyPlaced, m.JobID)
+	}
+	active := activeHolders(workers)
+	if len(active) < p.replication {
+		return nil, fmt.Errorf("%w: %d of %d for job %s", ErrInsufficientHolders, len(active), p.replication, m.JobID)
+	}
+
+	pl := &Placement{JobID: m.JobID, Holders: make([][]common.Address, m.NumShards())}
+	for i := range pl.Holders {
+		chosen := p.pick(active, nil, p.replication, shardSeed(seed, m.JobID, i))
+		pl.Holders[i] = addresses(chosen)
+	}
+
+	p.placements[m.JobID] = pl
+	return clonePlacement(pl), nil
+}
+
+// Placement returns a copy of a job's placement
+func (p *Placer) Placement(jobID string) (*Placement, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pl, ok := p.placements[jobID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPlacement, jobID)
+	}
+	return clonePlacement(pl), nil
+}
+
+// Load returns how many shard replicas a holder keeps
+func (p *Placer) Load(addr common.Address) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.load[addr]
+}
+
+// Remove forgets a job's placement and releases its holders' load
+func (p *Placer) Remove(jobID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pl, ok := p.placements[jobID]
+	if !ok {
+		return
+	}
+	for _, holders := range pl.Holders {
+		for _, h := range holders {
+			p.load[h]--
+		}
+	}
+	delete(p.placements, jobID)
+}
+
+// UnderReplicated returns every shard with fewer than ReplicationFactor
+// holders among the active workers, ordered by job and shard
+func (p *Placer) UnderReplicated(workers []*types.Worker) []Deficit {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.underReplicated(workers)
+}
+
+// underReplicated is UnderReplicated with the lock held
+func (p *Placer) underReplicated(workers []*types.Worker) []Deficit {
+	live := make(map[common.Address]bool)
+	for _, w := range activeHolders(workers) {
+		live[w.Address] = true
+	}
+
+	var deficits []Deficit
+	for _, id := range p.sortedJobIDs() {
+		for i, holders := range p.placements[id].Holders {
+			var alive []common.Address
+			for _, h := range holders {
+				if live[h] {
+					alive = append(alive, h)
+				}
+			}
+			if len(alive) < p.replication {
+				deficits = append(deficits, Deficit{JobID: id, Shard: i, Live: alive, Missing: p.replication - len(alive)})
+			}
+		}
+	}
+	return deficits
+}
+
+// Repair restores every under-replicated shard to ReplicationFactor live
+// holders where enough active workers exist. Holders that are no longer
+// active are dropped from the placement. It returns the transfers needed
+// to realize the new placement and any deficits it could not fix.
+func (p *Placer) Repair(workers []*types.Worker, seed common.Hash) ([]Transfer, []Deficit) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	deficits := p.underReplicated(workers)
+	active := activeHolders(workers)
+	byAddr := make(map[common.Address]*types.Worker, len(active))
+	for _, w := range active {
+		byAddr[w.Address] = w
+	}
+
+	var (
+		transfers []Transfer
+		unfixed   []Deficit
+	)
+	for _, d := range deficits {
+		pl := p.placements[d.JobID]
+		for _, h := range pl.Holders[d.Shard] {
+			if byAddr[h] == nil {
+				p.load[h]--
+			}
+		}
+
+		existing := make([]*types.Worker, 0, len(d.Live))
+		for _, h := range d.Live {
+			existing = append(existing, byAddr[h])
+		}
+		added := p.pick(active, existing, d.Missing, shardSeed(seed, d.JobID, d.Shard))
+		pl.Holders[d.Shard] = append(append([]common.Address(nil), d.Live...), addresses(added)...)
+
+		for _, w := range added {
+			t := Transfer{JobID: d.JobID, Shard: d.Shard, To: w.Address}
+			if len(d.Live) > 0 {
+				t.From = d.Live[0]
+			} else {
+				t.Rebuild = true
+			}
+			transfers = append(transfers, t)
+		}
+		if len(added) < d.Missing {
+			unfixed = append(unfixed, Deficit{
+				JobID:   d.JobID,
+				Shard:   d.Shard,
+				Live:    pl.Holders[d.Shard],
+				Missing: d.Missing - len(added),
+			})
+		}
+	}
+	return transfers, unfixed
+}
+
+// pick chooses up to n holders not already in existing. Each pick prefers a
+// holder whose ASN and region are both new to the shard, then one with a
+// new ASN, then any; within a preference level the least loaded holder
+// wins, with ties broken by the seed. Picked holders' load is recorded.
+func (p *Placer) pick(candidates, existing []*types.Worker, n int, seed common.Hash) []*types.Worker {
+	order := append([]*types.Worker(nil), candidates...)
+	sort.Slice(order, func(i, j int) bool { return order[i].Address.Hex() < order[j].Address.Hex() })
+	stream := randomness.NewStream(seed)
+	stream.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	sort.SliceStable(order, func(i, j int) bool { return p.load[order[i].Address] < p.load[order[j].Address] })
+
+	taken := make(map[common.Address]bool)
+	asns := make(map[string]bool)
+	regions := make(map[string]bool)
+	note := func(w *types.Worker) {
+		taken[w.Address] = true
+		asns[placementKey(w.Specs.ASN)] = true
+		regions[placementKey(w.Specs.Region)] = true
+	}
+	for _, w := range existing {
+		note(w)
+	}
+
+	var chosen []*types.Worker
+	for len(chosen) < n {
+		var best *types.Worker
+		bestLevel := 3
+		for _, w := range order {
+			if taken[w.Address] {
+				continue
+			}
+			level := 2
+			if !asns[placementKey(w.Specs.ASN)] {
+				level = 1
+				if !regions[placementKey(w.Specs.Region)] {
+					level = 0
+				}
+			}
+			if level < bestLevel {
+				best, bestLevel = w, level
+			}
+			if level == 0 {
+				break
+			}
+		}
+		if best == nil {
+			break
+		}
+		note(best)
+		p.load[best.Address]++
+		chosen = append(chosen, best)
+	}
+	return chosen
+}
+
+// sortedJobIDs returns placed job IDs in order
+func (p *Placer) sortedJobIDs() []string {
+	ids := make([]string, 0, len(p.placements))
+	for id := range p.placements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// activeHolders returns the active workers
+func activeHolders(workers []*types.Worker) []*types.Worker {
+	active := make([]*types.Worker, 0, len(workers))
+	for _, w := range workers {
+		if w.Status == types.WorkerStatusActive {
+			active = append(active, w)
+		}
+	}
+	return active
+}
+
+// shardSeed derives the tie-break seed for one shard
+func shardSeed(seed common.Hash, jobID string, shard int) common.Hash {
+	var s [8]byte
+	binary.BigEndian.PutUint64(s[:], uint64(shard))
+	return randomness.DeriveSeed(placementDomain, seed.Bytes(), []byte(jobID), s[:])
+}
+
+func addresses(workers []*types.Worker) []common.Address {
+	addrs := make([]common.Address, len(workers))
+	for i, w := range workers {
+		addrs[i] = w.Address
+	}
+	return addrs
+}
+
+func clonePlacement(pl *Placement) *Placement {
+	c := &Placement{JobID: pl.JobID, Holders: make([][]common.Address, len(pl.Holders))}
+	for i, h := range pl.Holders {
+		c.Holders[i] = append([]common.Address(nil), h...)
+	}
+	return c
+}
+
+// placementKey normalizes an ASN or region for comparison. Unknown values
+// all count as the same so they do not pass as diverse.
+func placementKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,191 @@
+package da
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// holders returns n active workers spread over three ASNs and three regions
+func holders(n int) []*types.Worker {
+	regions := []string{"us-west", "eu-central", "ap-south"}
+	workers := make([]*types.Worker, n)
+	for i := range workers {
+		workers[i] = &types.Worker{
+			Address: common.BigToAddress(big.NewInt(int64(i + 1))),
+			Status:  types.WorkerStatusActive,
+			Specs: types.WorkerSpecs{
+				ASN:    fmt.Sprintf("AS%d", 100+i%3),
+				Region: regions[(i/3)%3],
+			},
+		}
+	}
+	return workers
+}
+
+// placementManifest returns a manifest with the given number of shards
+func placementManifest(t *testing.T, jobID string, shards int) *Manifest {
+	m, _, err := Encode(jobID, testOutput(shards*16), 16, 1)
+	require.NoError(t, err)
+	require.Equal(t, shards, m.NumShards())
+	return m
+}
+
+func workerByAddr(workers []*types.Worker, addr common.Address) *types.Worker {
+	for _, w := range workers {
+		if w.Address == addr {
+			return w
+		}
+	}
+	return nil
+}
+
+func TestPlacer_PlaceIsDiverseAndBalanced(t *testing.T) {
+	p := NewPlacer(config.DefaultConfig().DA)
+	workers := holders(9)
+
+	pl, err := p.Place(placementManifest(t, "job-1", 30), workers, common.HexToHash("0x1"))
+	require.NoError(t, err)
+	require.Len(t, pl.Holders, 30)
+
+	for i, hs := range pl.Holders {
+		require.Len(t, hs, 3, "shard %d", i)
+		asns, regions := map[string]bool{}, map[string]bool{}
+		for _, h := range hs {
+			w := workerByAddr(workers, h)
+			asns[w.Specs.ASN] = true
+			regions[w.Specs.Region] = true
+		}
+		assert.Len(t, asns, 3, "shard %d ASNs", i)
+		assert.Len(t, regions, 3, "shard %d regions", i)
+	}
+
+	// 90 replicas over 9 holders; diversity can cost one replica of balance
+	total := 0
+	for _, w := range workers {
+		assert.InDelta(t, 10, p.Load(w.Address), 1)
+		total += p.Load(w.Address)
+	}
+	assert.Equal(t, 90, total)
+
+	_, err = p.Place(placementManifest(t, "job-1", 1), workers, common.Hash{})
+	assert.ErrorIs(t, err, ErrAlreadyPlaced)
+}
+
+func TestPlacer_Deterministic(t *testing.T) {
+	m := placementManifest(t, "job-1", 10)
+	a, err := NewPlacer(config.DefaultConfig().DA).Place(m, holders(9), common.HexToHash("0x1"))
+	require.NoError(t, err)
+	b, err := NewPlacer(config.DefaultConfig().DA).Place(m, holders(9), common.HexToHash("0x1"))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
+
+func TestPlacer_FallsBackWhenDiversityIsScarce(t *testing.T) {
+	p := NewPlacer(config.DefaultConfig().DA)
+	workers := holders(3)
+	for _, w := range workers {
+		w.Specs.ASN, w.Specs.Region = "AS1", "us-west"
+	}
+
+	pl, err := p.Place(placementManifest(t, "job-1", 2), workers, common.Hash{})
+	require.NoError(t, err)
+	assert.Len(t, pl.Holders[0], 3)
+
+	workers[0].Status = types.WorkerStatusInactive
+	_, err = NewPlacer(config.DefaultConfig().DA).Place(placementManifest(t, "job-2", 1), workers, common.Hash{})
+	assert.ErrorIs(t, err, ErrInsufficientHolders)
+}
+
+func TestPlacer_RepairCopiesFromLiveHolders(t *testing.T) {
+	p := NewPlacer(config.DefaultConfig().DA)
+	workers := holders(9)
+	_, err := p.Place(placementManifest(t, "job-1", 12), workers, common.HexToHash("0x1"))
+	require.NoError(t, err)
+	assert.Empty(t, p.UnderReplicated(workers))
+
+	offline := workers[4]
+	offline.Status = types.WorkerStatusInactive
+	deficits := p.UnderReplicated(workers)
+	require.NotEmpty(t, deficits)
+	for _, d := range deficits {
+		assert.Equal(t, 1, d.Missing)
+		assert.NotContains(t, d.Live, offline.Address)
+	}
+
+	transfers, unfixed := p.Repair(workers, common.HexToHash("0x2"))
+	assert.Empty(t, unfixed)
+	require.Len(t, transfers, len(deficits))
+	for _, tr := range transfers {
+		assert.False(t, tr.Rebuild)
+		assert.NotEqual(t, offline.Address, tr.To)
+		assert.NotEqual(t, common.Address{}, tr.From)
+	}
+
+	assert.Empty(t, p.UnderReplicated(workers))
+	assert.Equal(t, 0, p.Load(offline.Address))
+	pl, err := p.Placement("job-1")
+	require.NoError(t, err)
+	for _, hs := range pl.Holders {
+		assert.NotContains(t, hs, offline.Address)
+	}
+}
+
+func TestPlacer_RepairRebuildsLostShards(t *testing.T) {
+	cfg := config.DefaultConfig().DA
+	cfg.ReplicationFactor = 1
+	p := NewPlacer(cfg)
+	workers := holders(4)
+	pl, err := p.Place(placementManifest(t, "job-1", 4), workers, common.Hash{})
+	require.NoError(t, err)
+
+	lost := workerByAddr(workers, pl.Holders[0][0])
+	lost.Status = types.WorkerStatusSlashed
+
+	transfers, unfixed := p.Repair(workers, common.Hash{})
+	assert.Empty(t, unfixed)
+	require.NotEmpty(t, transfers)
+	for _, tr := range transfers {
+		assert.True(t, tr.Rebuild)
+		assert.Equal(t, common.Address{}, tr.From)
+	}
+}
+
+func TestPlacer_RepairReportsUnfixable(t *testing.T) {
+	p := NewPlacer(config.DefaultConfig().DA)
+	workers := holders(3)
+	_, err := p.Place(placementManifest(t, "job-1", 2), workers, common.Hash{})
+	require.NoError(t, err)
+
+	workers[0].Status = types.WorkerStatusInactive
+	transfers, unfixed := p.Repair(workers, common.Hash{})
+	assert.Empty(t, transfers)
+	require.Len(t, unfixed, 2)
+	assert.Equal(t, 1, unfixed[0].Missing)
+	assert.Len(t, unfixed[0].Live, 2)
+}
+
+func TestPlacer_Remove(t *testing.T) {
+	p := NewPlacer(config.DefaultConfig().DA)
+	workers := holders(3)
+	_, err := p.Place(placementManifest(t, "job-1", 2), workers, common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Load(workers[0].Address))
+
+	p.Remove("job-1")
+	assert.Equal(t, 0, p.Load(workers[0].Address))
+	_, err = p.Placement("job-1")
+	assert.ErrorIs(t, err, ErrUnknownPlacement)
+}
+
+func TestShardSeed_JobAndShardDoNotCollide(t *testing.T) {
+	seed := common.HexToHash("0x90")
+	assert.NotEqual(t, shardSeed(seed, "job-1", 12), shardSeed(seed, "job-11", 2))
+	assert.Equal(t, shardSeed(seed, "job-1", 12), shardSeed(seed, "job-1", 12))
+}
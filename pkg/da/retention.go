@@ -0,0 +1,284 @@
+package da
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+)
+
+// pinsFile holds the pinned job IDs inside the storage directory. Only
+// directories are jobs, so it never shadows one.
+const pinsFile = "pins.json"
+
+// GCEntry is a stored job considered by garbage collection
+type GCEntry struct {
+	JobID     string    `json:"job_id"`
+	Size      int64     `json:"size"` // in bytes
+	StoredAt  time.Time `json:"stored_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GCReport describes a garbage collection run. In a dry run Removed lists
+// what would have been deleted and nothing is touched.
+type GCReport struct {
+	At        time.Time `json:"at"`
+	DryRun    bool      `json:"dry_run"`
+	Removed   []GCEntry `json:"removed"`
+	Pinned    []GCEntry `json:"pinned"` // Expired but kept for a dispute
+	Retained  int       `json:"retained"`
+	Freed     int64     `json:"freed"` // in bytes
+	Used      int64     `json:"used"`  // in bytes, after collection
+	Quota     int64     `json:"quota"` // in bytes, 0 for unlimited
+	OverQuota bool      `json:"over_quota"`
+}
+
+// Retention decides how long stored job outputs are kept. A job is kept for
+// DAConfig.AvailabilityWindow plus PoPCConfig.FraudWindowTime after it was
+// stored, so it can be audited and challenged, and for as long as it is
+// pinned by a dispute. The disk quota never shortens that period: deleting
+// data a worker is still accountable for would be slashable, so a full disk
+// instead makes the store refuse new data.
+type Retention struct {
+	mu       sync.Mutex
+	store    *Store
+	placer   *Placer
+	keep     time.Duration
+	interval time.Duration
+	quota    int64 // in bytes
+	pins     map[string]bool
+}
+
+// NewRetention creates a retention manager for a store, loading any pins
+// saved in its storage directory. The disk quota is the store's.
+func NewRetention(store *Store, da config.DAConfig, popc config.PoPCConfig) (*Retention, error) {
+	r := &Retention{
+		store:    store,
+		keep:     da.AvailabilityWindow + popc.FraudWindowTime,
+		interval: da.AvailabilityWindow,
+		quota:    store.Quota(),
+		pins:     make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(filepath.Join(store.Dir(), pinsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("failed to parse DA pins: %w", err)
+	}
+	for _, id := range ids {
+		r.pins[id] = true
+	}
+	return r, nil
+}
+
+// SetPlacer sets a placer whose placements are removed with collected jobs
+func (r *Retention) SetPlacer(p *Placer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.placer = p
+}
+
+// Period returns how long a job is kept after it is stored
+func (r *Retention) Period() time.Duration {
+	return r.keep
+}
+
+// Pin keeps a job's data past its retention period, e.g. while the job is
+// under dispute
+func (r *Retention) Pin(jobID string) error {
+	if _, err := r.store.jobDir(jobID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pins[jobID] {
+		return nil
+	}
+	r.pins[jobID] = true
+	if err := r.savePins(); err != nil {
+		delete(r.pins, jobID)
+		return err
+	}
+	return nil
+}
+
+// Unpin releases a pin, letting the job expire normally
+func (r *Retention) Unpin(jobID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.pins[jobID] {
+		return nil
+	}
+	delete(r.pins, jobID)
+	if err := r.savePins(); err != nil {
+		r.pins[jobID] = true
+		return err
+	}
+	return nil
+}
+
+// Pinned reports whether a job is pinned
+func (r *Retention) Pinned(jobID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pins[jobID]
+}
+
+// Pins returns the pinned job IDs, sorted
+func (r *Retention) Pins() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.sortedPins()
+}
+
+// Usage returns the bytes used by every stored job
+func (r *Retention) Usage() (int64, error) {
+	return r.store.Usage()
+}
+
+// GC deletes every unpinned job whose retention period has ended at now,
+// removing its placement as well. With dryRun it only reports what it
+// would delete.
+func (r *Retention) GC(now time.Time, dryRun bool) (*GCReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := r.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &GCReport{At: now, DryRun: dryRun, Quota: r.quota}
+	for _, e := range entries {
+		switch {
+		case now.Before(e.ExpiresAt):
+			report.Retained++
+			report.Used += e.Size
+		case r.pins[e.JobID]:
+			report.Pinned = append(report.Pinned, e)
+			report.Used += e.Size
+		default:
+			if !dryRun {
+				if err := r.store.Delete(e.JobID); err != nil {
+					return report, fmt.Errorf("failed to delete job %s: %w", e.JobID, err)
+				}
+				if r.placer != nil {
+					r.placer.Remove(e.JobID)
+				}
+			}
+			report.Removed = append(report.Removed, e)
+			report.Freed += e.Size
+		}
+	}
+	report.OverQuota = r.quota > 0 && report.Used > r.quota
+	return report, nil
+}
+
+// Run collects garbage every AvailabilityWindow until ctx is cancelled,
+// passing each run's report or error to report
+func (r *Retention) Run(ctx context.Context, report func(*GCReport, error)) error {
+	interval := r.interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			res, err := r.GC(now, false)
+			if report != nil {
+				report(res, err)
+			}
+		}
+	}
+}
+
+// entries lists every stored job with its size and retention deadline
+func (r *Retention) entries() ([]GCEntry, error) {
+	ids, err := r.store.Jobs()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]GCEntry, 0, len(ids))
+	for _, id := range ids {
+		size, err := r.store.Size(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		storedAt, err := r.storedAt(id)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, GCEntry{
+			JobID:     id,
+			Size:      size,
+			StoredAt:  storedAt,
+			ExpiresAt: storedAt.Add(r.keep),
+		})
+	}
+	return entries, nil
+}
+
+// storedAt returns when a job was stored, falling back to its directory's
+// modification time for shards held without a manifest
+func (r *Retention) storedAt(jobID string) (time.Time, error) {
+	t, err := r.store.StoredAt(jobID)
+	if !errors.Is(err, ErrNotFound) {
+		return t, err
+	}
+	dir, err := r.store.jobDir(jobID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// savePins writes the pinned job IDs to the storage directory
+func (r *Retention) savePins() error {
+	data, err := json.MarshalIndent(r.sortedPins(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(r.store.Dir(), pinsFile), data)
+}
+
+// sortedPins returns the pinned job IDs in order
+func (r *Retention) sortedPins() []string {
+	ids := make([]string, 0, len(r.pins))
+	for id := range r.pins {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
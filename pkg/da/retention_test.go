@@ -0,0 +1,183 @@
+package da
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRetention returns a store and a retention manager keeping data
+// for 5m + 1h
+func newTestRetention(t *testing.T) (*Store, *Retention) {
+	s := newTestStore(t)
+	cfg := config.DefaultConfig()
+	r, err := NewRetention(s, cfg.DA, cfg.PoPC)
+	require.NoError(t, err)
+	require.Equal(t, 65*time.Minute, r.Period())
+	return s, r
+}
+
+// storeAged stores a job and backdates it to storedAt
+func storeAged(t *testing.T, s *Store, jobID string, storedAt time.Time) {
+	_, err := s.Put(jobID, testOutput(2000))
+	require.NoError(t, err)
+	require.NoError(t, os.Chtimes(filepath.Join(s.Dir(), jobID, manifestFile), storedAt, storedAt))
+}
+
+func TestRetention_GC(t *testing.T) {
+	s, r := newTestRetention(t)
+	now := time.Now()
+	storeAged(t, s, "old", now.Add(-2*time.Hour))
+	storeAged(t, s, "disputed", now.Add(-2*time.Hour))
+	storeAged(t, s, "fresh", now.Add(-30*time.Minute))
+	require.NoError(t, r.Pin("disputed"))
+
+	oldSize, err := s.Size("old")
+	require.NoError(t, err)
+	used, err := r.Usage()
+	require.NoError(t, err)
+
+	dry, err := r.GC(now, true)
+	require.NoError(t, err)
+	assert.True(t, dry.DryRun)
+	require.Len(t, dry.Removed, 1)
+	assert.Equal(t, "old", dry.Removed[0].JobID)
+	assert.Equal(t, oldSize, dry.Freed)
+	require.Len(t, dry.Pinned, 1)
+	assert.Equal(t, "disputed", dry.Pinned[0].JobID)
+	assert.Equal(t, 1, dry.Retained)
+	assert.Equal(t, used-oldSize, dry.Used)
+	_, err = s.Manifest("old")
+	require.NoError(t, err, "dry run must not delete")
+
+	res, err := r.GC(now, false)
+	require.NoError(t, err)
+	assert.Equal(t, dry.Removed, res.Removed)
+	_, err = s.Manifest("old")
+	assert.ErrorIs(t, err, ErrNotFound)
+	ids, err := s.Jobs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"disputed", "fresh"}, ids)
+
+	// Once the dispute is settled the job expires normally
+	require.NoError(t, r.Unpin("disputed"))
+	res, err = r.GC(now, false)
+	require.NoError(t, err)
+	require.Len(t, res.Removed, 1)
+	assert.Equal(t, "disputed", res.Removed[0].JobID)
+
+	// The fresh job is kept until its window ends
+	res, err = r.GC(now.Add(35*time.Minute), false)
+	require.NoError(t, err)
+	require.Len(t, res.Removed, 1)
+	assert.Equal(t, "fresh", res.Removed[0].JobID)
+	assert.Zero(t, res.Used)
+}
+
+func TestRetention_PinsPersist(t *testing.T) {
+	s, r := newTestRetention(t)
+	require.NoError(t, r.Pin("job-2"))
+	require.NoError(t, r.Pin("job-1"))
+	require.NoError(t, r.Pin("job-1"))
+	assert.ErrorIs(t, r.Pin("../x"), ErrInvalidJobID)
+
+	cfg := config.DefaultConfig()
+	reloaded, err := NewRetention(s, cfg.DA, cfg.PoPC)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-1", "job-2"}, reloaded.Pins())
+	assert.True(t, reloaded.Pinned("job-1"))
+
+	require.NoError(t, reloaded.Unpin("job-1"))
+	require.NoError(t, reloaded.Unpin("job-3"))
+	again, err := NewRetention(s, cfg.DA, cfg.PoPC)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-2"}, again.Pins())
+
+	// The pins file is not mistaken for a job
+	ids, err := s.Jobs()
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestRetention_Quota(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DA.StorageDir = filepath.Join(t.TempDir(), "da")
+	cfg.DA.ChunkSize = 1
+	cfg.DA.DiskQuota = 0
+	unlimited, err := NewStore(cfg.DA)
+	require.NoError(t, err)
+
+	// Data past the quota, e.g. stored before the quota was lowered
+	_, err = unlimited.Put("job-1", testOutput(600*1024))
+	require.NoError(t, err)
+	_, err = unlimited.Put("job-2", testOutput(600*1024))
+	require.NoError(t, err)
+
+	cfg.DA.DiskQuota = 1 // MB
+	s, err := NewStore(cfg.DA)
+	require.NoError(t, err)
+	r, err := NewRetention(s, cfg.DA, cfg.PoPC)
+	require.NoError(t, err)
+
+	// Data inside its retention period is never evicted for the quota
+	res, err := r.GC(time.Now(), false)
+	require.NoError(t, err)
+	assert.Empty(t, res.Removed)
+	assert.True(t, res.OverQuota)
+	assert.Equal(t, s.Quota(), res.Quota)
+}
+
+func TestRetention_GCRemovesPlacement(t *testing.T) {
+	s, r := newTestRetention(t)
+	p := NewPlacer(config.DefaultConfig().DA)
+	r.SetPlacer(p)
+
+	now := time.Now()
+	storeAged(t, s, "job-1", now.Add(-2*time.Hour))
+	m, err := s.Manifest("job-1")
+	require.NoError(t, err)
+	workers := holders(3)
+	_, err = p.Place(m, workers, common.Hash{})
+	require.NoError(t, err)
+
+	_, err = r.GC(now, true)
+	require.NoError(t, err)
+	_, err = p.Placement("job-1")
+	require.NoError(t, err)
+
+	_, err = r.GC(now, false)
+	require.NoError(t, err)
+	_, err = p.Placement("job-1")
+	assert.ErrorIs(t, err, ErrUnknownPlacement)
+	assert.Equal(t, 0, p.Load(workers[0].Address))
+}
+
+func TestRetention_Run(t *testing.T) {
+	s := newTestStore(t)
+	cfg := config.DefaultConfig()
+	cfg.DA.AvailabilityWindow = 20 * time.Millisecond
+	cfg.PoPC.FraudWindowTime = 0
+	r, err := NewRetention(s, cfg.DA, cfg.PoPC)
+	require.NoError(t, err)
+	storeAged(t, s, "job-1", time.Now().Add(-time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	removed := make(chan string, 1)
+	err = r.Run(ctx, func(res *GCReport, err error) {
+		require.NoError(t, err)
+		if len(res.Removed) > 0 {
+			removed <- res.Removed[0].JobID
+			cancel()
+		}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "job-1", <-removed)
+}
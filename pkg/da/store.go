@@ -0,0 +1,420 @@
+package da
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+)
+
+// File names inside a job's directory
+const (
+	manifestFile = "manifest.json"
+	shardPrefix  = "shard-"
+)
+
+var (
+	// ErrInvalidJobID is returned for a job ID that cannot be used as a directory name
+	ErrInvalidJobID = errors.New("da: invalid job ID")
+	// ErrNotFound is returned when a job or shard is not stored
+	ErrNotFound = errors.New("da: not found")
+	// ErrQuotaExceeded is returned when storing more data would exceed the disk quota
+	ErrQuotaExceeded = errors.New("da: disk quota exceeded")
+)
+
+// Store keeps erasure-coded job outputs on disk under DAConfig.StorageDir,
+// one directory per job holding its manifest and shards. Writes that would
+// take the stored shards past DAConfig.DiskQuota are refused.
+type Store struct {
+	mu        sync.Mutex // Serializes quota checks with the writes they admit
+	dir       string
+	chunkSize int // in bytes
+	rate      float64
+	quota     int64 // in bytes, 0 for unlimited
+	used      int64 // in bytes, kept current by every write and delete
+}
+
+// NewStore creates a store using DAConfig.StorageDir, ChunkSize (in KB),
+// ErasureCodingRate and DiskQuota (in MB). The storage directory is created
+// if needed and scanned once for the bytes already stored.
+func NewStore(cfg config.DAConfig) (*Store, error) {
+	if cfg.ChunkSize <= 0 {
+		return nil, fmt.Errorf("%w: %d KB", ErrInvalidChunkSize, cfg.ChunkSize)
+	}
+	if !validRate(cfg.ErasureCodingRate) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRate, cfg.ErasureCodingRate)
+	}
+	if err := os.MkdirAll(cfg.StorageDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create DA storage dir: %w", err)
+	}
+	s := &Store{
+		dir:       cfg.StorageDir,
+		chunkSize: cfg.ChunkSize * 1024,
+		rate:      cfg.ErasureCodingRate,
+		quota:     int64(cfg.DiskQuota) * 1024 * 1024,
+	}
+	used, err := s.scan()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan DA storage dir: %w", err)
+	}
+	s.used = used
+	return s, nil
+}
+
+// Dir returns the storage directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Quota returns the disk quota in bytes, 0 for unlimited
+func (s *Store) Quota() int64 {
+	return s.quota
+}
+
+// Usage returns the bytes used by every stored job
+func (s *Store) Usage() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.used, nil
+}
+
+// scan adds up the bytes used by every stored job on disk
+func (s *Store) scan() (int64, error) {
+	ids, err := s.Jobs()
+	if err != nil {
+		return 0, err
+	}
+	var used int64
+	for _, id := range ids {
+		size, err := s.Size(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		used += size
+	}
+	return used, nil
+}
+
+// Put erasure codes a job's output and stores its manifest and every shard
+func (s *Store) Put(jobID string, data []byte) (*Manifest, error) {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, shards, err := Encode(jobID, data, s.chunkSize, s.rate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Shards being overwritten free their old size
+	var growth int64
+	for i, shard := range shards {
+		growth += int64(len(shard)) - fileSize(filepath.Join(dir, shardName(i)))
+	}
+	if err := s.admit(growth); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	for i, shard := range shards {
+		if err := s.write(filepath.Join(dir, shardName(i)), shard); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.putManifest(dir, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PutManifest stores a job's manifest
+func (s *Store) PutManifest(m *Manifest) error {
+	dir, err := s.jobDir(m.JobID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return s.putManifest(dir, m)
+}
+
+// putManifest writes a manifest into its job directory. The caller holds s.mu.
+func (s *Store) putManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.write(filepath.Join(dir, manifestFile), data)
+}
+
+// Manifest loads a job's manifest and checks that it is valid
+func (s *Store) Manifest(jobID string) (*Manifest, error) {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: manifest for job %s", ErrNotFound, jobID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest for job %s: %w", jobID, err)
+	}
+	if m.JobID != jobID {
+		return nil, fmt.Errorf("%w: manifest for job %s is stored under %s", ErrInvalidManifest, m.JobID, jobID)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("job %s: %w", jobID, err)
+	}
+	return &m, nil
+}
+
+// PutShard stores one shard of a job after checking it against the manifest
+func (s *Store) PutShard(m *Manifest, i int, shard []byte) error {
+	if err := m.VerifyShard(i, shard); err != nil {
+		return err
+	}
+	dir, err := s.jobDir(m.JobID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(dir, shardName(i))
+	if err := s.admit(int64(len(shard)) - fileSize(path)); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return s.write(path, shard)
+}
+
+// Shard reads one stored shard of a job. It is not verified.
+func (s *Store) Shard(jobID string, i int) ([]byte, error) {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, shardName(i)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: shard %d of job %s", ErrNotFound, i, jobID)
+	}
+	return data, err
+}
+
+// DeleteShard removes one stored shard of a job, if present
+func (s *Store) DeleteShard(jobID string, i int) error {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(dir, shardName(i))
+	size := fileSize(path)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.used -= size
+	return nil
+}
+
+// Get reconstructs a job's output from whichever of its shards are stored
+// and intact
+func (s *Store) Get(jobID string) ([]byte, error) {
+	m, err := s.Manifest(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make([][]byte, m.NumShards())
+	for i := range shards {
+		shard, err := s.Shard(jobID, i)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		shards[i] = shard
+	}
+	return Reconstruct(m, shards)
+}
+
+// Delete removes a job's manifest and shards
+func (s *Store) Delete(jobID string) error {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size, err := s.Size(jobID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	s.used -= size
+	return nil
+}
+
+// Jobs returns the IDs of stored jobs, sorted
+func (s *Store) Jobs() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() {
+			ids = append(ids, e.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Size returns the bytes a job's manifest and shards take on disk
+func (s *Store) Size(jobID string) (int64, error) {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("%w: job %s", ErrNotFound, jobID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, e := range entries {
+		info, err := e.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+	}
+	return size, nil
+}
+
+// StoredAt returns when a job's manifest was last written. Put writes the
+// manifest after every shard, so this is when the job finished storing.
+func (s *Store) StoredAt(jobID string) (time.Time, error) {
+	dir, err := s.jobDir(jobID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	info, err := os.Stat(filepath.Join(dir, manifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, fmt.Errorf("%w: manifest for job %s", ErrNotFound, jobID)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// admit checks that size more bytes fit within the disk quota. Writes that
+// do not grow the store are always admitted. The caller holds s.mu.
+func (s *Store) admit(size int64) error {
+	if s.quota <= 0 || size <= 0 {
+		return nil
+	}
+	if s.used+size > s.quota {
+		return fmt.Errorf("%w: %d + %d of %d bytes", ErrQuotaExceeded, s.used, size, s.quota)
+	}
+	return nil
+}
+
+// write replaces a file in a job directory and updates the bytes used. The
+// caller holds s.mu.
+func (s *Store) write(path string, data []byte) error {
+	old := fileSize(path)
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+	s.used += int64(len(data)) - old
+	return nil
+}
+
+// jobDir returns the directory for a job, rejecting IDs that would escape
+// the storage directory
+func (s *Store) jobDir(jobID string) (string, error) {
+	if jobID == "" || jobID == "." || jobID == ".." || strings.ContainsAny(jobID, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidJobID, jobID)
+	}
+	return filepath.Join(s.dir, jobID), nil
+}
+
+func shardName(i int) string {
+	return fmt.Sprintf("%s%05d", shardPrefix, i)
+}
+
+// fileSize returns the size of a regular file, or 0 if it does not exist
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return 0
+	}
+	return info.Size()
+}
+
+// writeFile writes data through a temporary file so readers never see a
+// partial shard or manifest
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
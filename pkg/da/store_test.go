@@ -0,0 +1,264 @@
+package da
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore returns a store in a temporary directory with 1 KB chunks
+func newTestStore(t *testing.T) *Store {
+	cfg := config.DefaultConfig().DA
+	cfg.StorageDir = filepath.Join(t.TempDir(), "da")
+	cfg.ChunkSize = 1
+
+	s, err := NewStore(cfg)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewStore(t *testing.T) {
+	cfg := config.DefaultConfig().DA
+	cfg.StorageDir = filepath.Join(t.TempDir(), "nested", "da")
+
+	s, err := NewStore(cfg)
+	require.NoError(t, err)
+	assert.DirExists(t, s.Dir())
+
+	cfg.ChunkSize = 0
+	_, err = NewStore(cfg)
+	assert.ErrorIs(t, err, ErrInvalidChunkSize)
+
+	cfg.ChunkSize, cfg.ErasureCodingRate = 256, 0.9
+	_, err = NewStore(cfg)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func TestStore_Quota(t *testing.T) {
+	cfg := config.DefaultConfig().DA
+	cfg.StorageDir = filepath.Join(t.TempDir(), "da")
+	cfg.ChunkSize = 1
+	cfg.DiskQuota = 1 // MB
+	s, err := NewStore(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024*1024), s.Quota())
+
+	m, err := s.Put("job-1", testOutput(600*1024))
+	require.NoError(t, err)
+	used, err := s.Usage()
+	require.NoError(t, err)
+
+	// A second output would not fit and leaves nothing behind
+	_, err = s.Put("job-2", testOutput(600*1024))
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	_, err = s.Manifest("job-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+	after, err := s.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, used, after)
+
+	// Nor can shards be added one at a time past the quota
+	m3, shards, err := Encode("job-3", testOutput(600*1024), 1024, cfg.ErasureCodingRate)
+	require.NoError(t, err)
+	var putErr error
+	for i, shard := range shards {
+		if putErr = s.PutShard(m3, i, shard); putErr != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, putErr, ErrQuotaExceeded)
+
+	// Freeing space admits new data again
+	require.NoError(t, s.Delete(m.JobID))
+	require.NoError(t, s.Delete("job-3"))
+	_, err = s.Put("job-2", testOutput(600*1024))
+	assert.NoError(t, err)
+
+	// Overwriting a stored job does not count its old size twice
+	_, err = s.Put("job-2", testOutput(600*1024))
+	assert.NoError(t, err)
+}
+
+func TestStore_UsageTracksWrites(t *testing.T) {
+	cfg := config.DefaultConfig().DA
+	cfg.StorageDir = filepath.Join(t.TempDir(), "da")
+	cfg.ChunkSize = 1
+	s, err := NewStore(cfg)
+	require.NoError(t, err)
+
+	// scanned returns the usage a freshly opened store finds on disk
+	scanned := func() int64 {
+		reopened, err := NewStore(cfg)
+		require.NoError(t, err)
+		used, err := reopened.Usage()
+		require.NoError(t, err)
+		return used
+	}
+
+	m, err := s.Put("job-1", testOutput(5000))
+	require.NoError(t, err)
+	_, err = s.Put("job-2", testOutput(3000))
+	require.NoError(t, err)
+	shard, err := s.Shard("job-1", 0)
+	require.NoError(t, err)
+	require.NoError(t, s.PutShard(m, 0, shard))
+	require.NoError(t, s.PutManifest(m))
+	require.NoError(t, s.DeleteShard("job-1", 1))
+
+	used, err := s.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, scanned(), used)
+
+	require.NoError(t, s.Delete("job-1"))
+	require.NoError(t, s.Delete("job-missing"))
+	used, err = s.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, scanned(), used)
+	size, err := s.Size("job-2")
+	require.NoError(t, err)
+	assert.Equal(t, size, used)
+}
+
+func TestStore_PutGet(t *testing.T) {
+	s := newTestStore(t)
+	data := testOutput(10*1024 + 17)
+
+	m, err := s.Put("job-1", data)
+	require.NoError(t, err)
+	assert.Equal(t, 17, m.NumShards())
+
+	loaded, err := s.Manifest("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, m, loaded)
+
+	got, err := s.Get("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestStore_GetWithLostAndCorruptedShards(t *testing.T) {
+	s := newTestStore(t)
+	data := testOutput(10 * 1024)
+	m, err := s.Put("job-1", data)
+	require.NoError(t, err)
+
+	// 10 data + 5 parity: lose 3 and corrupt 2
+	for _, i := range []int{0, 4, 9} {
+		require.NoError(t, s.DeleteShard("job-1", i))
+	}
+	for _, i := range []int{2, 12} {
+		path := filepath.Join(s.Dir(), "job-1", shardName(i))
+		require.NoError(t, os.WriteFile(path, make([]byte, m.Stripes[0].ShardSize), 0o644))
+	}
+
+	got, err := s.Get("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	require.NoError(t, s.DeleteShard("job-1", 1))
+	_, err = s.Get("job-1")
+	assert.ErrorIs(t, err, ErrTooFewShards)
+}
+
+func TestStore_PutShard(t *testing.T) {
+	src := newTestStore(t)
+	dst := newTestStore(t)
+	data := testOutput(4096)
+
+	m, err := src.Put("job-1", data)
+	require.NoError(t, err)
+	require.NoError(t, dst.PutManifest(m))
+
+	for i := 0; i < m.NumShards(); i++ {
+		shard, err := src.Shard("job-1", i)
+		require.NoError(t, err)
+		require.NoError(t, dst.PutShard(m, i, shard))
+	}
+	got, err := dst.Get("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	err = dst.PutShard(m, 0, []byte("forged"))
+	assert.ErrorIs(t, err, ErrShardMismatch)
+}
+
+func TestStore_MissingAndInvalid(t *testing.T) {
+	s := newTestStore(t)
+
+	_, err := s.Manifest("job-x")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.Shard("job-x", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	for _, id := range []string{"", "..", "a/b", `a\b`} {
+		_, err := s.Put(id, []byte("data"))
+		assert.ErrorIs(t, err, ErrInvalidJobID, id)
+	}
+
+	_, err = s.Put("job-1", []byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, s.Delete("job-1"))
+	_, err = s.Get("job-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_RejectsInvalidManifest(t *testing.T) {
+	s := newTestStore(t)
+	m, err := s.Put("job-1", testOutput(3000))
+	require.NoError(t, err)
+
+	m.Size = -1
+	require.NoError(t, s.PutManifest(m))
+	_, err = s.Manifest("job-1")
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+	_, err = s.Get("job-1")
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+
+	// A valid manifest stored under another job's directory
+	m.Size = 3000
+	m.JobID = "job-2"
+	require.NoError(t, s.PutManifest(m))
+	require.NoError(t, os.Rename(filepath.Join(s.Dir(), "job-2", manifestFile), filepath.Join(s.Dir(), "job-1", manifestFile)))
+	_, err = s.Manifest("job-1")
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestStore_JobsSizeStoredAt(t *testing.T) {
+	s := newTestStore(t)
+	ids, err := s.Jobs()
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = s.Put("job-b", testOutput(3000))
+	require.NoError(t, err)
+	_, err = s.Put("job-a", testOutput(100))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(s.Dir(), "stray"), []byte("x"), 0o644))
+
+	ids, err = s.Jobs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-a", "job-b"}, ids)
+
+	small, err := s.Size("job-a")
+	require.NoError(t, err)
+	large, err := s.Size("job-b")
+	require.NoError(t, err)
+	assert.Greater(t, small, int64(100))
+	assert.Greater(t, large, small)
+
+	stored := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(filepath.Join(s.Dir(), "job-a", manifestFile), stored, stored))
+	at, err := s.StoredAt("job-a")
+	require.NoError(t, err)
+	assert.True(t, stored.Equal(at))
+
+	_, err = s.Size("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.StoredAt("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
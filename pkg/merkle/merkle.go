@@ -0,0 +1,180 @@
+// Package merkle builds binary Merkle commitments over job output chunks and
+// produces inclusion proofs for individual leaves
+package merkle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Prefixes separate leaf and interior hashes so that an interior node can
+// never be presented as a leaf (second-preimage protection)
+const (
+	leafPrefix byte = 0x00
+	nodePrefix byte = 0x01
+)
+
+var (
+	// ErrNoLeaves is returned when building a tree with no leaves
+	ErrNoLeaves = errors.New("merkle: tree must have at least one leaf")
+	// ErrInvalidChunkSize is returned when splitting data with a non-positive chunk size
+	ErrInvalidChunkSize = errors.New("merkle: chunk size must be positive")
+	// ErrIndexOutOfRange is returned when a proof is requested for a missing leaf
+	ErrIndexOutOfRange = errors.New("merkle: leaf index out of range")
+	// ErrInvalidProof is returned when a proof does not match the root
+	ErrInvalidProof = errors.New("merkle: invalid inclusion proof")
+)
+
+// Tree is a binary Merkle tree. An unpaired node at the end of a level is
+// promoted unchanged to the next level rather than duplicated.
+type Tree struct {
+	levels [][]common.Hash // levels[0] are the leaf hashes, the last level is the root
+}
+
+// Proof is an inclusion proof for a single leaf
+type Proof struct {
+	Index     int           `json:"index"`
+	NumLeaves int           `json:"num_leaves"`
+	Siblings  []common.Hash `json:"siblings"` // bottom-up
+}
+
+// HashLeaf returns the leaf hash of a chunk
+func HashLeaf(chunk []byte) common.Hash {
+	return crypto.Keccak256Hash([]byte{leafPrefix}, chunk)
+}
+
+// HashNode returns the hash of an interior node
+func HashNode(left, right common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte{nodePrefix}, left.Bytes(), right.Bytes())
+}
+
+// SplitChunks splits data into chunks of at most chunkSize bytes
+func SplitChunks(data []byte, chunkSize int) ([][]byte, error) {
+	if chunkSize <= 0 {
+		return nil, ErrInvalidChunkSize
+	}
+	if len(data) == 0 {
+		return nil, ErrNoLeaves
+	}
+
+	chunks := make([][]byte, 0, (len(data)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(data); start += chunkSize {
+		end := start + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, data[start:end])
+	}
+	return chunks, nil
+}
+
+// NewTree builds a tree over raw output chunks
+func NewTree(chunks [][]byte) (*Tree, error) {
+	leaves := make([]common.Hash, len(chunks))
+	for i, chunk := range chunks {
+		leaves[i] = HashLeaf(chunk)
+	}
+	return NewTreeFromLeaves(leaves)
+}
+
+// NewTreeFromLeaves builds a tree over precomputed leaf hashes
+func NewTreeFromLeaves(leaves []common.Hash) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+
+	level := make([]common.Hash, len(leaves))
+	copy(level, leaves)
+	levels := [][]common.Hash{level}
+
+	for len(level) > 1 {
+		next := make([]common.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, HashNode(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &Tree{levels: levels}, nil
+}
+
+// Root returns the Merkle root
+func (t *Tree) Root() common.Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// NumLeaves returns the number of leaves in the tree
+func (t *Tree) NumLeaves() int {
+	return len(t.levels[0])
+}
+
+// Leaf returns the leaf hash at index
+func (t *Tree) Leaf(index int) (common.Hash, error) {
+	if index < 0 || index >= t.NumLeaves() {
+		return common.Hash{}, fmt.Errorf("%w: %d", ErrIndexOutOfRange, index)
+	}
+	return t.levels[0][index], nil
+}
+
+// Proof returns the inclusion proof for the leaf at index
+func (t *Tree) Proof(index int) (*Proof, error) {
+	if index < 0 || index >= t.NumLeaves() {
+		return nil, fmt.Errorf("%w: %d", ErrIndexOutOfRange, index)
+	}
+
+	proof := &Proof{Index: index, NumLeaves: t.NumLeaves()}
+	pos := index
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := pos ^ 1
+		if sibling < len(level) {
+			proof.Siblings = append(proof.Siblings, level[sibling])
+		}
+		pos /= 2
+	}
+
+	return proof, nil
+}
+
+// Verify checks that chunk is the leaf at proof.Index under root
+func Verify(root common.Hash, chunk []byte, proof *Proof) error {
+	return VerifyLeaf(root, HashLeaf(chunk), proof)
+}
+
+// VerifyLeaf checks that leaf is the leaf hash at proof.Index under root
+func VerifyLeaf(root, leaf common.Hash, proof *Proof) error {
+	if proof == nil || proof.Index < 0 || proof.Index >= proof.NumLeaves {
+		return ErrInvalidProof
+	}
+
+	hash := leaf
+	pos, width, used := proof.Index, proof.NumLeaves, 0
+	for width > 1 {
+		// The last node of an odd-width level has no sibling and is promoted
+		if pos^1 < width {
+			if used >= len(proof.Siblings) {
+				return ErrInvalidProof
+			}
+			if pos%2 == 0 {
+				hash = HashNode(hash, proof.Siblings[used])
+			} else {
+				hash = HashNode(proof.Siblings[used], hash)
+			}
+			used++
+		}
+		pos /= 2
+		width = (width + 1) / 2
+	}
+
+	if used != len(proof.Siblings) || hash != root {
+		return ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testChunks(n int) [][]byte {
+	chunks := make([][]byte, n)
+	for i := range chunks {
+		chunks[i] = []byte(fmt.Sprintf("chunk-%d", i))
+	}
+	return chunks
+}
+
+func TestNewTree_Errors(t *testing.T) {
+	_, err := NewTree(nil)
+	assert.ErrorIs(t, err, ErrNoLeaves)
+}
+
+func TestTree_SingleLeaf(t *testing.T) {
+	tree, err := NewTree(testChunks(1))
+	require.NoError(t, err)
+
+	assert.Equal(t, HashLeaf([]byte("chunk-0")), tree.Root())
+
+	proof, err := tree.Proof(0)
+	require.NoError(t, err)
+	assert.Empty(t, proof.Siblings)
+	assert.NoError(t, Verify(tree.Root(), []byte("chunk-0"), proof))
+}
+
+func TestTree_ProofsAllSizes(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 7, 8, 9, 16, 33} {
+		t.Run(fmt.Sprintf("leaves=%d", n), func(t *testing.T) {
+			chunks := testChunks(n)
+			tree, err := NewTree(chunks)
+			require.NoError(t, err)
+			assert.Equal(t, n, tree.NumLeaves())
+
+			for i, chunk := range chunks {
+				proof, err := tree.Proof(i)
+				require.NoError(t, err)
+				assert.NoError(t, Verify(tree.Root(), chunk, proof))
+			}
+		})
+	}
+}
+
+func TestTree_OddLevelPromotion(t *testing.T) {
+	chunks := testChunks(3)
+	tree, err := NewTree(chunks)
+	require.NoError(t, err)
+
+	expected := HashNode(
+		HashNode(HashLeaf(chunks[0]), HashLeaf(chunks[1])),
+		HashLeaf(chunks[2]),
+	)
+	assert.Equal(t, expected, tree.Root())
+}
+
+func TestVerify_RejectsTampering(t *testing.T) {
+	chunks := testChunks(10)
+	tree, err := NewTree(chunks)
+	require.NoError(t, err)
+
+	proof, err := tree.Proof(4)
+	require.NoError(t, err)
+
+	// Wrong chunk
+	assert.ErrorIs(t, Verify(tree.Root(), []byte("forged"), proof), ErrInvalidProof)
+
+	// Wrong root
+	assert.ErrorIs(t, Verify(common.HexToHash("0x01"), chunks[4], proof), ErrInvalidProof)
+
+	// Wrong index
+	moved := *proof
+	moved.Index = 5
+	assert.ErrorIs(t, Verify(tree.Root(), chunks[4], &moved), ErrInvalidProof)
+
+	// Truncated siblings
+	short := *proof
+	short.Siblings = proof.Siblings[:len(proof.Siblings)-1]
+	assert.ErrorIs(t, Verify(tree.Root(), chunks[4], &short), ErrInvalidProof)
+
+	// Interior node presented as a leaf
+	assert.ErrorIs(t, VerifyLeaf(tree.Root(), HashNode(HashLeaf(chunks[4]), HashLeaf(chunks[5])), proof), ErrInvalidProof)
+
+	assert.ErrorIs(t, Verify(tree.Root(), chunks[4], nil), ErrInvalidProof)
+}
+
+func TestTree_ProofOutOfRange(t *testing.T) {
+	tree, err := NewTree(testChunks(4))
+	require.NoError(t, err)
+
+	_, err = tree.Proof(4)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	_, err = tree.Proof(-1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+}
+
+func TestSplitChunks(t *testing.T) {
+	chunks, err := SplitChunks([]byte("abcdefghij"), 4)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("abcd"), []byte("efgh"), []byte("ij")}, chunks)
+
+	_, err = SplitChunks([]byte("abc"), 0)
+	assert.ErrorIs(t, err, ErrInvalidChunkSize)
+
+	_, err = SplitChunks(nil, 4)
+	assert.ErrorIs(t, err, ErrNoLeaves)
+}
+
+func BenchmarkNewTree(b *testing.B) {
+	chunks := testChunks(4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewTree(chunks)
+	}
+}
@@ -0,0 +1,107 @@
+package popc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/merkle"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+)
+
+// ErrTreeMismatch is returned when a worker's tree does not match the challenged commitment
+var ErrTreeMismatch = errors.New("popc: tree does not match challenged output root")
+
+// LeafProof is a single sampled output chunk together with its inclusion proof
+type LeafProof struct {
+	Index int           `json:"index"`
+	Chunk []byte        `json:"chunk"`
+	Proof *merkle.Proof `json:"proof"`
+}
+
+// ChallengeResponse is a worker's answer to a challenge set
+type ChallengeResponse struct {
+	JobID  string      `json:"job_id"`
+	Leaves []LeafProof `json:"leaves"`
+}
+
+// ProofReport summarizes the inclusion-proof check of a challenge response
+type ProofReport struct {
+	Verified []int `json:"verified"` // indices with a valid proof
+	Missing  []int `json:"missing"`  // challenged indices with no answer
+	Invalid  []int `json:"invalid"`  // indices whose proof does not match the root
+}
+
+// OK reports whether every challenged index was answered with a valid proof
+func (r *ProofReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Invalid) == 0
+}
+
+// CommitOutput builds the Merkle tree over an executing job's output chunks
+// and commits its root and chunk count as the job's OutputRoot and
+// OutputSize. The root alone does not fix the number of leaves, so proofs
+// are checked against both.
+func CommitOutput(job *types.Job, chunks [][]byte) (*merkle.Tree, error) {
+	tree, err := merkle.NewTree(chunks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build output tree: %w", err)
+	}
+
+	if err := job.Commit(tree.Root()); err != nil {
+		return nil, err
+	}
+	job.OutputSize = tree.NumLeaves()
+	return tree, nil
+}
+
+// Respond answers a challenge set from the worker's output tree and chunks
+func Respond(cs *ChallengeSet, tree *merkle.Tree, chunks [][]byte) (*ChallengeResponse, error) {
+	if tree.Root() != cs.OutputRoot || tree.NumLeaves() != cs.OutputSize {
+		return nil, ErrTreeMismatch
+	}
+
+	resp := &ChallengeResponse{
+		JobID:  cs.JobID,
+		Leaves: make([]LeafProof, 0, len(cs.Indices)),
+	}
+	for _, idx := range cs.Indices {
+		proof, err := tree.Proof(idx)
+		if err != nil {
+			return nil, err
+		}
+		resp.Leaves = append(resp.Leaves, LeafProof{
+			Index: idx,
+			Chunk: chunks[idx],
+			Proof: proof,
+		})
+	}
+
+	return resp, nil
+}
+
+// VerifyResponse checks every answered leaf against the challenged output
+// root and reports which challenged indices are verified, missing or invalid
+func VerifyResponse(cs *ChallengeSet, resp *ChallengeResponse) *ProofReport {
+	answers := make(map[int]LeafProof, len(resp.Leaves))
+	if resp.JobID == cs.JobID {
+		for _, leaf := range resp.Leaves {
+			answers[leaf.Index] = leaf
+		}
+	}
+
+	report := &ProofReport{}
+	for _, idx := range cs.Indices {
+		leaf, ok := answers[idx]
+		switch {
+		case !ok:
+			report.Missing = append(report.Missing, idx)
+		case leaf.Proof == nil || leaf.Proof.Index != idx || leaf.Proof.NumLeaves != cs.OutputSize:
+			report.Invalid = append(report.Invalid, idx)
+		case merkle.Verify(cs.OutputRoot, leaf.Chunk, leaf.Proof) != nil:
+			report.Invalid = append(report.Invalid, idx)
+		default:
+			report.Verified = append(report.Verified, idx)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,104 @@
+package popc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func outputChunks(n int) [][]byte {
+	chunks := make([][]byte, n)
+	for i := range chunks {
+		chunks[i] = []byte(fmt.Sprintf("result-%d", i))
+	}
+	return chunks
+}
+
+// executingJob returns a job that is ready to commit its output
+func executingJob(id string) *types.Job {
+	return &types.Job{ID: id, Status: types.JobStatusExecuting}
+}
+
+func TestCommitOutput(t *testing.T) {
+	job := executingJob("job-commit")
+
+	_, err := CommitOutput(job, nil)
+	assert.Error(t, err)
+
+	tree, err := CommitOutput(job, outputChunks(100))
+	require.NoError(t, err)
+	assert.Equal(t, tree.Root(), job.OutputRoot)
+	assert.Equal(t, types.JobStatusCommitted, job.Status)
+
+	// A job commits only once
+	_, err = CommitOutput(job, outputChunks(100))
+	assert.ErrorIs(t, err, types.ErrInvalidTransition)
+}
+
+func TestRespondAndVerify(t *testing.T) {
+	job := executingJob("job-respond")
+	chunks := outputChunks(500)
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig().PoPC
+	cfg.SampleSize = 50
+	cs, err := NewSampler(cfg).Challenge(job, len(chunks), common.HexToHash("0x10"))
+	require.NoError(t, err)
+
+	resp, err := Respond(cs, tree, chunks)
+	require.NoError(t, err)
+	assert.Len(t, resp.Leaves, 50)
+
+	report := VerifyResponse(cs, resp)
+	assert.True(t, report.OK())
+	assert.Equal(t, cs.Indices, report.Verified)
+}
+
+func TestVerifyResponse_MissingAndInvalid(t *testing.T) {
+	job := executingJob("job-bad")
+	chunks := outputChunks(200)
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig().PoPC
+	cfg.SampleSize = 10
+	cs, err := NewSampler(cfg).Challenge(job, len(chunks), common.HexToHash("0x11"))
+	require.NoError(t, err)
+
+	resp, err := Respond(cs, tree, chunks)
+	require.NoError(t, err)
+
+	// Drop one answer and forge another
+	missing := resp.Leaves[0].Index
+	resp.Leaves = resp.Leaves[1:]
+	forged := resp.Leaves[0].Index
+	resp.Leaves[0].Chunk = []byte("forged")
+
+	report := VerifyResponse(cs, resp)
+	assert.False(t, report.OK())
+	assert.Equal(t, []int{missing}, report.Missing)
+	assert.Equal(t, []int{forged}, report.Invalid)
+	assert.Len(t, report.Verified, 8)
+}
+
+func TestRespond_TreeMismatch(t *testing.T) {
+	job := executingJob("job-mismatch")
+	chunks := outputChunks(20)
+	_, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	cs, err := NewSampler(config.DefaultConfig().PoPC).Challenge(job, len(chunks), common.HexToHash("0x12"))
+	require.NoError(t, err)
+
+	other, err := CommitOutput(executingJob("job-other"), outputChunks(21))
+	require.NoError(t, err)
+
+	_, err = Respond(cs, other, chunks)
+	assert.ErrorIs(t, err, ErrTreeMismatch)
+}
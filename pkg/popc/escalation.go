@@ -0,0 +1,158 @@
+package popc
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Escalation defaults
+const (
+	DefaultEscalationFactor = 4 // Each sampled round checks this many times more indices
+	DefaultSampleRounds     = 2 // Sampled rounds before falling back to full re-execution
+)
+
+// Domain tag for per-round seed derivation
+const roundDomain = "axionax/popc/round/v1"
+
+// ErrRoundsExhausted is returned when a job's PoPC rounds have already concluded
+var ErrRoundsExhausted = errors.New("popc: no escalation rounds left")
+
+// Checker recomputes the output element at index and reports whether the
+// worker's chunk is correct
+type Checker func(index int, chunk []byte) bool
+
+// Verdict is the outcome of evaluating a PoPC round
+type Verdict string
+
+const (
+	VerdictPass     Verdict = "pass"     // Round passed, the job result is accepted
+	VerdictEscalate Verdict = "escalate" // Round failed, a larger round has been scheduled
+	VerdictFail     Verdict = "fail"     // Round failed and no further escalation applies
+)
+
+// Escalator runs PoPC in rounds. When AdaptiveEscalation is enabled, a round
+// with any mismatch or missing proof schedules a larger sampled round, and
+// after DefaultSampleRounds sampled rounds a full re-execution round.
+type Escalator struct {
+	cfg    config.PoPCConfig
+	factor int
+	rounds int
+}
+
+// NewEscalator creates an escalator for the given PoPC configuration
+func NewEscalator(cfg config.PoPCConfig) *Escalator {
+	return &Escalator{
+		cfg:    cfg,
+		factor: DefaultEscalationFactor,
+		rounds: DefaultSampleRounds,
+	}
+}
+
+// NextRound returns the number and kind of the job's next round
+func (e *Escalator) NextRound(job *types.Job) (int, types.PoPCRoundKind) {
+	round := len(job.PoPCRounds) + 1
+	if round > e.rounds {
+		return round, types.PoPCRoundFull
+	}
+	return round, types.PoPCRoundSample
+}
+
+// NextChallenge builds the challenge set for the job's next round. Every
+// round uses a fresh seed derived from the base seed and the round number.
+func (e *Escalator) NextChallenge(job *types.Job, outputSize int, seed common.Hash) (*ChallengeSet, error) {
+	if e.concluded(job) {
+		return nil, ErrRoundsExhausted
+	}
+
+	round, kind := e.NextRound(job)
+
+	cfg := e.cfg
+	if kind == types.PoPCRoundFull {
+		cfg.SampleSize = outputSize
+	} else {
+		for i := 1; i < round; i++ {
+			cfg.SampleSize *= e.factor
+		}
+	}
+
+	return NewSampler(cfg).Challenge(job, outputSize, roundSeed(seed, round))
+}
+
+// Evaluate checks a worker's response to the job's current round, records the
+// round on the job at now and returns the verdict. The round's challenge set
+// is re-derived from the base seed, so cs must be exactly the set
+// NextChallenge built for this round.
+func (e *Escalator) Evaluate(job *types.Job, seed common.Hash, cs *ChallengeSet, resp *ChallengeResponse, check Checker, now time.Time) (Verdict, error) {
+	if e.concluded(job) {
+		return "", ErrRoundsExhausted
+	}
+	if err := checkChallengeJob(job, cs); err != nil {
+		return "", err
+	}
+	expected, err := e.NextChallenge(job, cs.OutputSize, seed)
+	if err != nil {
+		return "", err
+	}
+	if err := matchChallenge(expected, cs); err != nil {
+		return "", err
+	}
+
+	round, kind := e.NextRound(job)
+	report := VerifyResponse(cs, resp)
+
+	chunks := make(map[int][]byte, len(resp.Leaves))
+	for _, leaf := range resp.Leaves {
+		chunks[leaf.Index] = leaf.Chunk
+	}
+
+	mismatches := 0
+	for _, idx := range report.Verified {
+		if !check(idx, chunks[idx]) {
+			mismatches++
+		}
+	}
+
+	record := types.PoPCRound{
+		Round:      round,
+		Kind:       kind,
+		Seed:       cs.Seed,
+		Challenged: len(cs.Indices),
+		Mismatches: mismatches,
+		Missing:    len(report.Missing) + len(report.Invalid),
+		CheckedAt:  now,
+	}
+	record.Passed = record.Mismatches == 0 && record.Missing == 0
+	job.PoPCRounds = append(job.PoPCRounds, record)
+
+	switch {
+	case record.Passed:
+		return VerdictPass, nil
+	case e.cfg.AdaptiveEscalation && kind != types.PoPCRoundFull:
+		return VerdictEscalate, nil
+	default:
+		return VerdictFail, nil
+	}
+}
+
+// concluded reports whether the job's last round ended PoPC for it
+func (e *Escalator) concluded(job *types.Job) bool {
+	n := len(job.PoPCRounds)
+	if n == 0 {
+		return false
+	}
+	last := job.PoPCRounds[n-1]
+	return last.Passed || last.Kind == types.PoPCRoundFull || !e.cfg.AdaptiveEscalation
+}
+
+// roundSeed derives the seed for a given round from the base seed
+func roundSeed(seed common.Hash, round int) common.Hash {
+	var r [8]byte
+	binary.BigEndian.PutUint64(r[:], uint64(round))
+	return randomness.DeriveSeed(roundDomain, seed.Bytes(), r[:])
+}
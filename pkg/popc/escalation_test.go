@@ -0,0 +1,196 @@
+package popc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/merkle"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cheatingOutput returns chunks where every index in bad was computed wrongly
+func cheatingOutput(n int, bad map[int]bool) [][]byte {
+	chunks := outputChunks(n)
+	for idx := range bad {
+		chunks[idx] = []byte("wrong")
+	}
+	return chunks
+}
+
+// badRange marks every index in [lo, hi) as wrongly computed
+func badRange(lo, hi int) map[int]bool {
+	bad := make(map[int]bool, hi-lo)
+	for i := lo; i < hi; i++ {
+		bad[i] = true
+	}
+	return bad
+}
+
+func honestChecker(index int, chunk []byte) bool {
+	return string(chunk) == fmt.Sprintf("result-%d", index)
+}
+
+func escalationConfig() config.PoPCConfig {
+	cfg := config.DefaultConfig().PoPC
+	cfg.SampleSize = 20
+	cfg.StratifiedSampling = false
+	cfg.AdaptiveEscalation = true
+	return cfg
+}
+
+func runRound(t *testing.T, e *Escalator, job *types.Job, tree *merkle.Tree, chunks [][]byte) (*ChallengeSet, Verdict) {
+	cs, err := e.NextChallenge(job, len(chunks), common.HexToHash("0x30"))
+	require.NoError(t, err)
+	resp, err := Respond(cs, tree, chunks)
+	require.NoError(t, err)
+	verdict, err := e.Evaluate(job, common.HexToHash("0x30"), cs, resp, honestChecker, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	return cs, verdict
+}
+
+func TestEscalator_HonestWorkerPasses(t *testing.T) {
+	job := executingJob("job-honest")
+	chunks := outputChunks(1000)
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	e := NewEscalator(escalationConfig())
+	cs, verdict := runRound(t, e, job, tree, chunks)
+
+	assert.Equal(t, VerdictPass, verdict)
+	require.Len(t, job.PoPCRounds, 1)
+	assert.True(t, job.PoPCRounds[0].Passed)
+	assert.Equal(t, types.PoPCRoundSample, job.PoPCRounds[0].Kind)
+	assert.Equal(t, 20, job.PoPCRounds[0].Challenged)
+	assert.Equal(t, cs.Seed, job.PoPCRounds[0].Seed)
+
+	_, err = e.NextChallenge(job, len(chunks), common.HexToHash("0x30"))
+	assert.ErrorIs(t, err, ErrRoundsExhausted)
+}
+
+func TestEscalator_CheaterEscalatesToFull(t *testing.T) {
+	// Every index is wrong, so every round fails
+	job := executingJob("job-cheat")
+	chunks := cheatingOutput(1000, badRange(0, 1000))
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	e := NewEscalator(escalationConfig())
+
+	_, verdict := runRound(t, e, job, tree, chunks)
+	assert.Equal(t, VerdictEscalate, verdict)
+
+	_, verdict = runRound(t, e, job, tree, chunks)
+	assert.Equal(t, VerdictEscalate, verdict)
+	assert.Equal(t, 80, job.PoPCRounds[1].Challenged)
+
+	_, verdict = runRound(t, e, job, tree, chunks)
+	assert.Equal(t, VerdictFail, verdict)
+
+	require.Len(t, job.PoPCRounds, 3)
+	assert.Equal(t, types.PoPCRoundFull, job.PoPCRounds[2].Kind)
+	assert.Equal(t, 1000, job.PoPCRounds[2].Challenged)
+	assert.Equal(t, 1000, job.PoPCRounds[2].Mismatches)
+	assert.NotEqual(t, job.PoPCRounds[0].Seed, job.PoPCRounds[1].Seed)
+
+	_, err = e.NextChallenge(job, len(chunks), common.HexToHash("0x30"))
+	assert.ErrorIs(t, err, ErrRoundsExhausted)
+}
+
+func TestEscalator_MissingProofEscalates(t *testing.T) {
+	job := executingJob("job-missing")
+	chunks := outputChunks(500)
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	e := NewEscalator(escalationConfig())
+	cs, err := e.NextChallenge(job, len(chunks), common.HexToHash("0x31"))
+	require.NoError(t, err)
+	resp, err := Respond(cs, tree, chunks)
+	require.NoError(t, err)
+	resp.Leaves = resp.Leaves[:len(resp.Leaves)-1]
+
+	verdict, err := e.Evaluate(job, common.HexToHash("0x31"), cs, resp, honestChecker, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	assert.Equal(t, VerdictEscalate, verdict)
+	assert.Equal(t, 1, job.PoPCRounds[0].Missing)
+	assert.Equal(t, 0, job.PoPCRounds[0].Mismatches)
+}
+
+func TestEscalator_DisabledFailsImmediately(t *testing.T) {
+	cfg := escalationConfig()
+	cfg.AdaptiveEscalation = false
+
+	job := executingJob("job-no-escalation")
+	chunks := cheatingOutput(100, badRange(0, 100))
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	e := NewEscalator(cfg)
+	_, verdict := runRound(t, e, job, tree, chunks)
+	assert.Equal(t, VerdictFail, verdict)
+
+	_, err = e.NextChallenge(job, len(chunks), common.HexToHash("0x30"))
+	assert.ErrorIs(t, err, ErrRoundsExhausted)
+}
+
+func TestEscalator_RejectsForeignChallenge(t *testing.T) {
+	job := executingJob("job-a")
+	chunks := outputChunks(100)
+	_, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	e := NewEscalator(escalationConfig())
+	cs, err := e.NextChallenge(job, len(chunks), common.HexToHash("0x32"))
+	require.NoError(t, err)
+
+	other := &types.Job{ID: "job-b", OutputRoot: job.OutputRoot}
+	_, err = e.Evaluate(other, common.HexToHash("0x32"), cs, &ChallengeResponse{}, honestChecker, time.Unix(1700000000, 0))
+	assert.ErrorIs(t, err, ErrChallengeMismatch)
+}
+
+func TestEscalator_RejectsSubstitutedChallenge(t *testing.T) {
+	job := executingJob("job-substituted")
+	chunks := cheatingOutput(500, badRange(0, 250))
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+
+	seed := common.HexToHash("0x33")
+	now := time.Unix(1700000000, 0)
+	e := NewEscalator(escalationConfig())
+
+	// A set the worker picked to avoid the bad range, with honest proofs
+	easy := &ChallengeSet{JobID: job.ID, OutputRoot: job.OutputRoot, OutputSize: len(chunks), Seed: roundSeed(seed, 1)}
+	for i := 0; i < escalationConfig().SampleSize; i++ {
+		easy.Indices = append(easy.Indices, 250+i)
+	}
+	resp, err := Respond(easy, tree, chunks)
+	require.NoError(t, err)
+	_, err = e.Evaluate(job, seed, easy, resp, honestChecker, now)
+	assert.ErrorIs(t, err, ErrChallengeMismatch)
+
+	// A genuine set for another seed is not this round's set either
+	foreign, err := e.NextChallenge(job, len(chunks), common.HexToHash("0x34"))
+	require.NoError(t, err)
+	resp, err = Respond(foreign, tree, chunks)
+	require.NoError(t, err)
+	_, err = e.Evaluate(job, seed, foreign, resp, honestChecker, now)
+	assert.ErrorIs(t, err, ErrChallengeMismatch)
+
+	// Neither attempt counts as a round
+	assert.Empty(t, job.PoPCRounds)
+
+	cs, err := e.NextChallenge(job, len(chunks), seed)
+	require.NoError(t, err)
+	resp, err = Respond(cs, tree, chunks)
+	require.NoError(t, err)
+	_, err = e.Evaluate(job, seed, cs, resp, honestChecker, now)
+	require.NoError(t, err)
+	require.Len(t, job.PoPCRounds, 1)
+	assert.Equal(t, now, job.PoPCRounds[0].CheckedAt)
+}
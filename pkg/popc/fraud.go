@@ -0,0 +1,193 @@
+package popc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/merkle"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrUnknownJob is returned for jobs that are not tracked
+	ErrUnknownJob = errors.New("popc: job is not in a fraud window")
+	// ErrAlreadyTracked is returned when opening a window twice for a job
+	ErrAlreadyTracked = errors.New("popc: job already has an open fraud window")
+	// ErrNotValidatable is returned when opening a window for a job that has not committed its output
+	ErrNotValidatable = errors.New("popc: job is not ready for validation")
+	// ErrWindowClosed is returned when a fraud proof arrives after the window closed
+	ErrWindowClosed = errors.New("popc: fraud window has closed")
+	// ErrInvalidFraudProof is returned when a fraud proof does not prove fraud
+	ErrInvalidFraudProof = errors.New("popc: invalid fraud proof")
+)
+
+// FraudProof shows that a worker committed an incorrect result: the chunk
+// is proven to be part of the job's output root, and re-execution disagrees
+type FraudProof struct {
+	JobID      string         `json:"job_id"`
+	Challenger common.Address `json:"challenger"`
+	Index      int            `json:"index"`
+	Chunk      []byte         `json:"chunk"`
+	Proof      *merkle.Proof  `json:"proof"`
+}
+
+// Settlement is the final outcome of a job's fraud window
+type Settlement struct {
+	JobID      string          `json:"job_id"`
+	Status     types.JobStatus `json:"status"` // completed or slashed
+	SettledAt  time.Time       `json:"settled_at"`
+	FraudProof *FraudProof     `json:"fraud_proof,omitempty"`
+}
+
+type fraudWindow struct {
+	job      *types.Job
+	closesAt time.Time
+}
+
+// FraudWindowTracker keeps jobs in validating status until their fraud
+// window closes. A valid fraud proof within the window slashes the job;
+// otherwise the job completes when the window closes.
+type FraudWindowTracker struct {
+	mu      sync.Mutex
+	window  time.Duration
+	windows map[string]*fraudWindow
+}
+
+// NewFraudWindowTracker creates a tracker using PoPCConfig.FraudWindowTime
+func NewFraudWindowTracker(cfg config.PoPCConfig) *FraudWindowTracker {
+	return &FraudWindowTracker{
+		window:  cfg.FraudWindowTime,
+		windows: make(map[string]*fraudWindow),
+	}
+}
+
+// Open starts the fraud window for a committed job and moves it to validating
+func (t *FraudWindowTracker) Open(job *types.Job, now time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.windows[job.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyTracked, job.ID)
+	}
+	if job.Status != types.JobStatusCommitted && job.Status != types.JobStatusValidating {
+		return fmt.Errorf("%w: %s is %s", ErrNotValidatable, job.ID, job.Status)
+	}
+	if job.OutputRoot == (common.Hash{}) {
+		return ErrNoOutputRoot
+	}
+	if job.OutputSize <= 0 {
+		return fmt.Errorf("%w: job %s has no committed output size", ErrEmptyOutput, job.ID)
+	}
+
+	if job.Status == types.JobStatusCommitted {
+		if err := job.BeginValidation(); err != nil {
+			return err
+		}
+	}
+	t.windows[job.ID] = &fraudWindow{job: job, closesAt: now.Add(t.window)}
+	return nil
+}
+
+// FinalAt returns when the job's payment becomes final, assuming no fraud
+// proof is accepted before then
+func (t *FraudWindowTracker) FinalAt(jobID string) (time.Time, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w, ok := t.windows[jobID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrUnknownJob, jobID)
+	}
+	return w.closesAt, nil
+}
+
+// Pending returns the number of jobs with an open fraud window
+func (t *FraudWindowTracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.windows)
+}
+
+// SubmitFraudProof checks a fraud proof against the job's output root and
+// the given checker. A valid proof within the window slashes the job.
+func (t *FraudWindowTracker) SubmitFraudProof(fp *FraudProof, check Checker, now time.Time) (*Settlement, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w, ok := t.windows[fp.JobID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJob, fp.JobID)
+	}
+	if !now.Before(w.closesAt) {
+		return nil, fmt.Errorf("%w: %s closed at %s", ErrWindowClosed, fp.JobID, w.closesAt.Format(time.RFC3339))
+	}
+
+	if fp.Proof == nil || fp.Proof.Index != fp.Index {
+		return nil, fmt.Errorf("%w: proof does not cover index %d", ErrInvalidFraudProof, fp.Index)
+	}
+	if fp.Proof.NumLeaves != w.job.OutputSize {
+		return nil, fmt.Errorf("%w: proof is over %d leaves, committed output has %d", ErrInvalidFraudProof, fp.Proof.NumLeaves, w.job.OutputSize)
+	}
+	if err := merkle.Verify(w.job.OutputRoot, fp.Chunk, fp.Proof); err != nil {
+		return nil, fmt.Errorf("%w: chunk is not part of the committed output", ErrInvalidFraudProof)
+	}
+	if check(fp.Index, fp.Chunk) {
+		return nil, fmt.Errorf("%w: committed result at index %d is correct", ErrInvalidFraudProof, fp.Index)
+	}
+
+	if err := w.job.Slash(); err != nil {
+		return nil, err
+	}
+	delete(t.windows, fp.JobID)
+
+	return &Settlement{
+		JobID:      fp.JobID,
+		Status:     types.JobStatusSlashed,
+		SettledAt:  now,
+		FraudProof: fp,
+	}, nil
+}
+
+// Advance completes every job whose fraud window has closed by now and
+// returns their settlements ordered by job ID. A closed window whose job can
+// no longer complete, e.g. because it failed elsewhere while the window was
+// open, is dropped and reported in the returned error alongside the
+// settlements of the others.
+func (t *FraudWindowTracker) Advance(now time.Time) ([]Settlement, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var closed []string
+	for id, w := range t.windows {
+		if !now.Before(w.closesAt) {
+			closed = append(closed, id)
+		}
+	}
+	sort.Strings(closed)
+
+	var (
+		settled []Settlement
+		errs    []error
+	)
+	for _, id := range closed {
+		w := t.windows[id]
+		delete(t.windows, id)
+		if err := w.job.Complete(w.closesAt); err != nil {
+			errs = append(errs, fmt.Errorf("failed to settle job %s: %w", id, err))
+			continue
+		}
+
+		settled = append(settled, Settlement{
+			JobID:     id,
+			Status:    types.JobStatusCompleted,
+			SettledAt: w.closesAt,
+		})
+	}
+	return settled, errors.Join(errs...)
+}
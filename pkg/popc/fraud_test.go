@@ -0,0 +1,190 @@
+package popc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/merkle"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func committedJob(t *testing.T, id string, chunks [][]byte) (*types.Job, *merkle.Tree) {
+	job := executingJob(id)
+	tree, err := CommitOutput(job, chunks)
+	require.NoError(t, err)
+	return job, tree
+}
+
+func TestFraudWindow_CompletesAfterWindow(t *testing.T) {
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	job, _ := committedJob(t, "job-window", outputChunks(10))
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, tracker.Open(job, start))
+	assert.Equal(t, types.JobStatusValidating, job.Status)
+
+	finalAt, err := tracker.FinalAt(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(time.Hour), finalAt)
+
+	// Still validating just before the window closes
+	settled, err := tracker.Advance(start.Add(59 * time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, settled)
+	assert.Equal(t, types.JobStatusValidating, job.Status)
+
+	settled, err = tracker.Advance(start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, settled, 1)
+	assert.Equal(t, types.JobStatusCompleted, settled[0].Status)
+	assert.Equal(t, types.JobStatusCompleted, job.Status)
+	require.NotNil(t, job.CompletedAt)
+	assert.Equal(t, finalAt, *job.CompletedAt)
+	assert.Equal(t, 0, tracker.Pending())
+}
+
+func TestFraudWindow_ValidProofSlashes(t *testing.T) {
+	chunks := cheatingOutput(10, map[int]bool{3: true})
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	job, tree := committedJob(t, "job-fraud", chunks)
+	start := time.Now()
+	require.NoError(t, tracker.Open(job, start))
+
+	proof, err := tree.Proof(3)
+	require.NoError(t, err)
+
+	settlement, err := tracker.SubmitFraudProof(&FraudProof{
+		JobID:      job.ID,
+		Challenger: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Index:      3,
+		Chunk:      chunks[3],
+		Proof:      proof,
+	}, honestChecker, start.Add(10*time.Minute))
+	require.NoError(t, err)
+
+	assert.Equal(t, types.JobStatusSlashed, settlement.Status)
+	assert.Equal(t, types.JobStatusSlashed, job.Status)
+	assert.Nil(t, job.CompletedAt)
+	settled, err := tracker.Advance(start.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, settled)
+}
+
+func TestFraudWindow_RejectsInvalidProofs(t *testing.T) {
+	chunks := outputChunks(10)
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	job, tree := committedJob(t, "job-honest-window", chunks)
+	start := time.Now()
+	require.NoError(t, tracker.Open(job, start))
+
+	proof, err := tree.Proof(2)
+	require.NoError(t, err)
+
+	// Correct result
+	_, err = tracker.SubmitFraudProof(&FraudProof{JobID: job.ID, Index: 2, Chunk: chunks[2], Proof: proof}, honestChecker, start)
+	assert.ErrorIs(t, err, ErrInvalidFraudProof)
+
+	// Chunk the worker never committed
+	_, err = tracker.SubmitFraudProof(&FraudProof{JobID: job.ID, Index: 2, Chunk: []byte("made up"), Proof: proof}, honestChecker, start)
+	assert.ErrorIs(t, err, ErrInvalidFraudProof)
+
+	// Proof for another index
+	_, err = tracker.SubmitFraudProof(&FraudProof{JobID: job.ID, Index: 3, Chunk: chunks[2], Proof: proof}, honestChecker, start)
+	assert.ErrorIs(t, err, ErrInvalidFraudProof)
+
+	// After the window
+	_, err = tracker.SubmitFraudProof(&FraudProof{JobID: job.ID, Index: 2, Chunk: chunks[2], Proof: proof}, honestChecker, start.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrWindowClosed)
+
+	// Unknown job
+	_, err = tracker.SubmitFraudProof(&FraudProof{JobID: "nope"}, honestChecker, start)
+	assert.ErrorIs(t, err, ErrUnknownJob)
+
+	assert.Equal(t, types.JobStatusValidating, job.Status)
+}
+
+func TestFraudWindow_RejectsProofOverForgedLeafCount(t *testing.T) {
+	// With 3 leaves the root is HashNode(HashNode(l0, l1), l2), which is also
+	// a valid 2-leaf root with HashNode(l0, l1) as the left leaf. Without the
+	// committed size, chunk 2 could be "proven" at index 1 of 2 and the
+	// honest worker slashed for the mismatch.
+	chunks := outputChunks(3)
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	job, _ := committedJob(t, "job-forged-size", chunks)
+	assert.Equal(t, 3, job.OutputSize)
+	start := time.Now()
+	require.NoError(t, tracker.Open(job, start))
+
+	forged := &merkle.Proof{
+		Index:     1,
+		NumLeaves: 2,
+		Siblings:  []common.Hash{merkle.HashNode(merkle.HashLeaf(chunks[0]), merkle.HashLeaf(chunks[1]))},
+	}
+	require.NoError(t, merkle.Verify(job.OutputRoot, chunks[2], forged))
+
+	_, err := tracker.SubmitFraudProof(&FraudProof{JobID: job.ID, Index: 1, Chunk: chunks[2], Proof: forged}, honestChecker, start)
+	assert.ErrorIs(t, err, ErrInvalidFraudProof)
+	assert.Equal(t, types.JobStatusValidating, job.Status)
+}
+
+func TestFraudWindow_OpenErrors(t *testing.T) {
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	now := time.Now()
+
+	pending := &types.Job{ID: "job-pending", Status: types.JobStatusPending}
+	assert.ErrorIs(t, tracker.Open(pending, now), ErrNotValidatable)
+
+	noRoot := &types.Job{ID: "job-no-root", Status: types.JobStatusCommitted}
+	assert.ErrorIs(t, tracker.Open(noRoot, now), ErrNoOutputRoot)
+
+	noSize := &types.Job{ID: "job-no-size", Status: types.JobStatusCommitted, OutputRoot: common.HexToHash("0x01")}
+	assert.ErrorIs(t, tracker.Open(noSize, now), ErrEmptyOutput)
+
+	job, _ := committedJob(t, "job-twice", outputChunks(4))
+	require.NoError(t, tracker.Open(job, now))
+	assert.ErrorIs(t, tracker.Open(job, now), ErrAlreadyTracked)
+
+	_, err := tracker.FinalAt("missing")
+	assert.ErrorIs(t, err, ErrUnknownJob)
+}
+
+func TestFraudWindow_AdvanceOrdersByJobID(t *testing.T) {
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	start := time.Now()
+
+	for _, id := range []string{"job-c", "job-a", "job-b"} {
+		job, _ := committedJob(t, id, outputChunks(4))
+		require.NoError(t, tracker.Open(job, start))
+	}
+
+	settled, err := tracker.Advance(start.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Len(t, settled, 3)
+	assert.Equal(t, "job-a", settled[0].JobID)
+	assert.Equal(t, "job-b", settled[1].JobID)
+	assert.Equal(t, "job-c", settled[2].JobID)
+}
+
+func TestFraudWindow_AdvanceReportsUnsettledJobs(t *testing.T) {
+	tracker := NewFraudWindowTracker(config.DefaultConfig().PoPC)
+	start := time.Now()
+
+	failed, _ := committedJob(t, "job-failed", outputChunks(4))
+	require.NoError(t, tracker.Open(failed, start))
+	ok, _ := committedJob(t, "job-ok", outputChunks(4))
+	require.NoError(t, tracker.Open(ok, start))
+
+	// Failed elsewhere while its window was open
+	require.NoError(t, failed.Fail())
+
+	settled, err := tracker.Advance(start.Add(2 * time.Hour))
+	assert.ErrorIs(t, err, types.ErrInvalidTransition)
+	assert.ErrorContains(t, err, "job-failed")
+	require.Len(t, settled, 1)
+	assert.Equal(t, "job-ok", settled[0].JobID)
+	assert.Equal(t, 0, tracker.Pending())
+}
@@ -0,0 +1,89 @@
+package popc
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrInvalidFraction is returned for corruption fractions outside (0, 1]
+	ErrInvalidFraction = errors.New("popc: corrupted fraction must be in (0, 1]")
+	// ErrInvalidConfidence is returned for confidence targets outside (0, 1)
+	ErrInvalidConfidence = errors.New("popc: confidence must be in (0, 1)")
+)
+
+// DetectionProbability returns the probability that a uniform sample of
+// sampleSize distinct indices hits at least one corrupted result, when a
+// fraction corruptFraction of an output with outputSize elements is wrong.
+//
+// An outputSize of zero or less models an unbounded output, where the
+// probability is 1 - (1-f)^s. For finite outputs the exact hypergeometric
+// probability is used, which is never lower than the unbounded bound.
+func DetectionProbability(outputSize int, corruptFraction float64, sampleSize int) (float64, error) {
+	if corruptFraction <= 0 || corruptFraction > 1 {
+		return 0, ErrInvalidFraction
+	}
+	if sampleSize <= 0 {
+		return 0, ErrInvalidSampleSize
+	}
+
+	if outputSize <= 0 {
+		return 1 - math.Pow(1-corruptFraction, float64(sampleSize)), nil
+	}
+
+	corrupted := corruptedCount(outputSize, corruptFraction)
+	if sampleSize > outputSize-corrupted {
+		return 1, nil
+	}
+
+	// P(miss) = prod_{i<s} (N-C-i)/(N-i), accumulated in log space
+	logMiss := 0.0
+	for i := 0; i < sampleSize; i++ {
+		logMiss += math.Log(float64(outputSize-corrupted-i)) - math.Log(float64(outputSize-i))
+	}
+	return 1 - math.Exp(logMiss), nil
+}
+
+// MinSampleSize returns the smallest sample size whose detection probability
+// reaches confidence. An outputSize of zero or less models an unbounded output.
+func MinSampleSize(outputSize int, corruptFraction, confidence float64) (int, error) {
+	if corruptFraction <= 0 || corruptFraction > 1 {
+		return 0, ErrInvalidFraction
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return 0, ErrInvalidConfidence
+	}
+
+	if outputSize <= 0 {
+		if corruptFraction == 1 {
+			return 1, nil
+		}
+		return int(math.Ceil(math.Log(1-confidence) / math.Log(1-corruptFraction))), nil
+	}
+
+	corrupted := corruptedCount(outputSize, corruptFraction)
+	target := math.Log(1 - confidence)
+	logMiss := 0.0
+	for s := 1; s <= outputSize-corrupted; s++ {
+		i := s - 1
+		logMiss += math.Log(float64(outputSize-corrupted-i)) - math.Log(float64(outputSize-i))
+		if logMiss <= target {
+			return s, nil
+		}
+	}
+	return outputSize - corrupted + 1, nil
+}
+
+// corruptedCount is the number of corrupted elements implied by a fraction,
+// rounded up so that any positive fraction corrupts at least one element
+func corruptedCount(outputSize int, corruptFraction float64) int {
+	// The epsilon keeps products like 0.07*100 = 7.000000000000001 from rounding up
+	c := int(math.Ceil(corruptFraction*float64(outputSize) - 1e-9))
+	if c < 1 {
+		c = 1
+	}
+	if c > outputSize {
+		c = outputSize
+	}
+	return c
+}
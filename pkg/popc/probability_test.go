@@ -0,0 +1,95 @@
+package popc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectionProbability_Unbounded(t *testing.T) {
+	// 1 - 0.99^1000 ≈ 0.999957
+	p, err := DetectionProbability(0, 0.01, 1000)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.999957, p, 1e-6)
+
+	p, err = DetectionProbability(0, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, p)
+}
+
+func TestDetectionProbability_Finite(t *testing.T) {
+	// One corrupted element out of 10, sampling 5: P = 5/10
+	p, err := DetectionProbability(10, 0.1, 5)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, p, 1e-12)
+
+	// Sampling more than the clean elements always detects
+	p, err = DetectionProbability(10, 0.5, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, p)
+
+	// Finite outputs detect at least as well as the unbounded bound
+	finite, err := DetectionProbability(5000, 0.01, 600)
+	require.NoError(t, err)
+	unbounded, err := DetectionProbability(0, 0.01, 600)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, finite, unbounded)
+}
+
+func TestDetectionProbability_Errors(t *testing.T) {
+	_, err := DetectionProbability(100, 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidFraction)
+
+	_, err = DetectionProbability(100, 1.5, 10)
+	assert.ErrorIs(t, err, ErrInvalidFraction)
+
+	_, err = DetectionProbability(100, 0.1, 0)
+	assert.ErrorIs(t, err, ErrInvalidSampleSize)
+}
+
+func TestMinSampleSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputSize int
+		fraction   float64
+		confidence float64
+	}{
+		{"Unbounded 1%", 0, 0.01, 0.999},
+		{"Unbounded 5%", 0, 0.05, 0.99},
+		{"Finite 1%", 100000, 0.01, 0.999},
+		{"Small output", 50, 0.02, 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := MinSampleSize(tt.outputSize, tt.fraction, tt.confidence)
+			require.NoError(t, err)
+
+			p, err := DetectionProbability(tt.outputSize, tt.fraction, s)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, p, tt.confidence)
+
+			if s > 1 {
+				p, err = DetectionProbability(tt.outputSize, tt.fraction, s-1)
+				require.NoError(t, err)
+				assert.Less(t, p, tt.confidence)
+			}
+		})
+	}
+}
+
+func TestMinSampleSize_DefaultConfigMeetsTarget(t *testing.T) {
+	s, err := MinSampleSize(0, 0.01, 0.999)
+	require.NoError(t, err)
+	assert.Equal(t, 688, s)
+	assert.LessOrEqual(t, s, 1000)
+}
+
+func TestMinSampleSize_Errors(t *testing.T) {
+	_, err := MinSampleSize(100, 0.01, 1)
+	assert.ErrorIs(t, err, ErrInvalidConfidence)
+
+	_, err = MinSampleSize(100, -0.1, 0.9)
+	assert.ErrorIs(t, err, ErrInvalidFraction)
+}
@@ -0,0 +1,93 @@
+package popc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/asr"
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Domain tag for redundancy seed derivation
+const redundancyDomain = "axionax/popc/redundancy/v1"
+
+// ErrNoReplicaCandidate is returned when no worker is independent of the primary
+var ErrNoReplicaCandidate = errors.New("popc: no independent replica worker available")
+
+// ReplicaAssignment pairs a job's primary worker with an independent replica
+type ReplicaAssignment struct {
+	JobID   string         `json:"job_id"`
+	Primary common.Address `json:"primary"`
+	Replica common.Address `json:"replica"`
+}
+
+// ReplicaCheck is the comparison of primary and replica output roots
+type ReplicaCheck struct {
+	ReplicaAssignment
+	PrimaryRoot common.Hash `json:"primary_root"`
+	ReplicaRoot common.Hash `json:"replica_root"`
+	Agree       bool        `json:"agree"`
+}
+
+// NeedsFullVerification reports whether the disagreement must be resolved by
+// full re-execution
+func (c *ReplicaCheck) NeedsFullVerification() bool {
+	return !c.Agree
+}
+
+// RedundancyScheduler selects RedundancyRate of jobs for replicated execution
+// and has the router pick their replicas
+type RedundancyScheduler struct {
+	cfg    config.PoPCConfig
+	router *asr.Router
+}
+
+// NewRedundancyScheduler creates a scheduler for the given PoPC configuration
+// that selects replicas with router
+func NewRedundancyScheduler(cfg config.PoPCConfig, router *asr.Router) *RedundancyScheduler {
+	return &RedundancyScheduler{cfg: cfg, router: router}
+}
+
+// NeedsReplica reports whether the job is selected for replicated execution.
+// The choice is unpredictable without the seed but reproducible with it.
+func (r *RedundancyScheduler) NeedsReplica(job *types.Job, seed common.Hash) bool {
+	if r.cfg.RedundancyRate <= 0 {
+		return false
+	}
+	stream := randomness.NewStream(randomness.DeriveSeed(redundancyDomain, seed.Bytes(), []byte(job.ID)))
+	return stream.Float64() < r.cfg.RedundancyRate
+}
+
+// Schedule returns a replica assignment if the job is selected for
+// redundancy, or nil if it is not. The replica is chosen by
+// asr.Router.SelectReplica, so it is operated independently of the primary
+// and of the job's validators.
+func (r *RedundancyScheduler) Schedule(job *types.Job, primary *types.Worker, validators []common.Address, candidates []*types.Worker, seed common.Hash) (*ReplicaAssignment, error) {
+	if !r.NeedsReplica(job, seed) {
+		return nil, nil
+	}
+
+	replica, err := r.router.SelectReplica(job, primary, validators, candidates, seed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: job %s: %w", ErrNoReplicaCandidate, job.ID, err)
+	}
+
+	return &ReplicaAssignment{
+		JobID:   job.ID,
+		Primary: primary.Address,
+		Replica: replica.Address,
+	}, nil
+}
+
+// CompareReplica compares the primary and replica output roots
+func CompareReplica(a *ReplicaAssignment, primaryRoot, replicaRoot common.Hash) *ReplicaCheck {
+	return &ReplicaCheck{
+		ReplicaAssignment: *a,
+		PrimaryRoot:       primaryRoot,
+		ReplicaRoot:       replicaRoot,
+		Agree:             primaryRoot == replicaRoot,
+	}
+}
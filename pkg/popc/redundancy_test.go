@@ -0,0 +1,82 @@
+package popc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/asr"
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorker(addr, asn, org string) *types.Worker {
+	return &types.Worker{
+		Address: common.HexToAddress(addr),
+		Status:  types.WorkerStatusActive,
+		Specs: types.WorkerSpecs{
+			ASN:          asn,
+			Organization: org,
+		},
+	}
+}
+
+func TestRedundancyScheduler_Rate(t *testing.T) {
+	r := NewRedundancyScheduler(config.DefaultConfig().PoPC, nil)
+	seed := common.HexToHash("0x40")
+
+	selected := 0
+	for i := 0; i < 20000; i++ {
+		if r.NeedsReplica(&types.Job{ID: fmt.Sprintf("job-%d", i)}, seed) {
+			selected++
+		}
+	}
+
+	// 2.5% of 20000 = 500
+	assert.InDelta(t, 500, selected, 100)
+}
+
+func TestRedundancyScheduler_Reproducible(t *testing.T) {
+	r := NewRedundancyScheduler(config.PoPCConfig{RedundancyRate: 0.5}, nil)
+	job := &types.Job{ID: "job-repro"}
+
+	a := r.NeedsReplica(job, common.HexToHash("0x41"))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, a, r.NeedsReplica(job, common.HexToHash("0x41")))
+	}
+
+	assert.False(t, NewRedundancyScheduler(config.PoPCConfig{}, nil).NeedsReplica(job, common.HexToHash("0x41")))
+}
+
+func TestSchedule_AndCompare(t *testing.T) {
+	router := asr.NewRouter(config.DefaultConfig().ASR)
+	r := NewRedundancyScheduler(config.PoPCConfig{RedundancyRate: 1}, router)
+	primary := testWorker("0x01", "AS100", "Acme")
+	replica := testWorker("0x02", "AS200", "Indie")
+	job := &types.Job{ID: "job-schedule"}
+
+	assignment, err := r.Schedule(job, primary, nil, []*types.Worker{primary, replica}, common.HexToHash("0x44"))
+	require.NoError(t, err)
+	require.NotNil(t, assignment)
+	assert.Equal(t, replica.Address, assignment.Replica)
+
+	agree := CompareReplica(assignment, common.HexToHash("0xaa"), common.HexToHash("0xaa"))
+	assert.True(t, agree.Agree)
+	assert.False(t, agree.NeedsFullVerification())
+
+	disagree := CompareReplica(assignment, common.HexToHash("0xaa"), common.HexToHash("0xbb"))
+	assert.True(t, disagree.NeedsFullVerification())
+
+	// The replica must not share the primary's operator nor be a validator
+	sameOrg := testWorker("0x03", "AS300", "acme")
+	_, err = r.Schedule(job, primary, []common.Address{replica.Address}, []*types.Worker{primary, replica, sameOrg}, common.HexToHash("0x44"))
+	assert.ErrorIs(t, err, ErrNoReplicaCandidate)
+	assert.ErrorIs(t, err, asr.ErrNoCandidates)
+
+	// Not selected: no assignment and no error
+	none, err := NewRedundancyScheduler(config.PoPCConfig{}, router).Schedule(job, primary, nil, nil, common.HexToHash("0x44"))
+	assert.NoError(t, err)
+	assert.Nil(t, none)
+}
@@ -0,0 +1,231 @@
+// Package popc implements Proof-of-Probabilistic-Checking for compute jobs
+package popc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Domain tag for challenge seed derivation
+const challengeDomain = "axionax/popc/challenge/v1"
+
+var (
+	// ErrNoOutputRoot is returned when a job has not committed an output root
+	ErrNoOutputRoot = errors.New("popc: job has no committed output root")
+	// ErrEmptyOutput is returned when the committed output has no elements
+	ErrEmptyOutput = errors.New("popc: output size must be positive")
+	// ErrInvalidSampleSize is returned when the configured sample size is not positive
+	ErrInvalidSampleSize = errors.New("popc: sample size must be positive")
+	// ErrChallengeMismatch is returned when a challenge set cannot be reproduced
+	ErrChallengeMismatch = errors.New("popc: challenge set does not match seed")
+)
+
+// ChallengeSet is the list of output indices a validator must check for a job
+type ChallengeSet struct {
+	JobID      string      `json:"job_id"`
+	OutputRoot common.Hash `json:"output_root"`
+	OutputSize int         `json:"output_size"`
+	Seed       common.Hash `json:"seed"`
+	Strata     []Stratum   `json:"strata,omitempty"` // empty for uniform sampling
+	Indices    []int       `json:"indices"`          // sorted ascending, no duplicates
+}
+
+// Sampler derives PoPC challenge sets from a job's committed output
+type Sampler struct {
+	cfg config.PoPCConfig
+}
+
+// NewSampler creates a sampler for the given PoPC configuration
+func NewSampler(cfg config.PoPCConfig) *Sampler {
+	return &Sampler{cfg: cfg}
+}
+
+// Config returns the sampler's PoPC configuration
+func (s *Sampler) Config() config.PoPCConfig {
+	return s.cfg
+}
+
+// Challenge returns the challenge set for a job whose committed output has
+// outputSize elements. The same job, output size and seed always yield the
+// same indices, so any validator can reproduce and audit the set.
+//
+// With StratifiedSampling enabled the output is split into contiguous
+// chunk-range strata and every stratum is sampled; otherwise indices are
+// drawn uniformly from the whole output.
+func (s *Sampler) Challenge(job *types.Job, outputSize int, seed common.Hash) (*ChallengeSet, error) {
+	if !s.cfg.StratifiedSampling {
+		return s.challenge(job, outputSize, seed, nil)
+	}
+	return s.challenge(job, outputSize, seed, ChunkStrata(outputSize, s.strataCount(outputSize)))
+}
+
+// ChallengeStrata returns a stratified challenge set over caller-supplied
+// strata, regardless of the StratifiedSampling setting
+func (s *Sampler) ChallengeStrata(job *types.Job, outputSize int, seed common.Hash, strata []Stratum) (*ChallengeSet, error) {
+	if len(strata) == 0 {
+		return nil, ErrInvalidStrata
+	}
+	return s.challenge(job, outputSize, seed, strata)
+}
+
+// Verify recomputes a challenge set under the sampler's own configuration
+// and checks that it matches the given one. With StratifiedSampling enabled
+// the strata are derived from the output size, never taken from cs, so a
+// prover cannot pick strata that steer the sample.
+func (s *Sampler) Verify(job *types.Job, cs *ChallengeSet) error {
+	if !s.cfg.StratifiedSampling {
+		return s.verify(job, cs, nil)
+	}
+	return s.verify(job, cs, ChunkStrata(cs.OutputSize, s.strataCount(cs.OutputSize)))
+}
+
+// VerifyStrata checks a challenge set built by ChallengeStrata against the
+// strata the verifier trusts
+func (s *Sampler) VerifyStrata(job *types.Job, cs *ChallengeSet, strata []Stratum) error {
+	if len(strata) == 0 {
+		return ErrInvalidStrata
+	}
+	return s.verify(job, cs, strata)
+}
+
+// verify recomputes a challenge set over the given strata and compares it
+func (s *Sampler) verify(job *types.Job, cs *ChallengeSet, strata []Stratum) error {
+	if err := checkChallengeJob(job, cs); err != nil {
+		return err
+	}
+	expected, err := s.challenge(job, cs.OutputSize, cs.Seed, strata)
+	if err != nil {
+		return err
+	}
+	return matchChallenge(expected, cs)
+}
+
+// checkChallengeJob checks that a challenge set is for the job's committed
+// output and its committed size
+func checkChallengeJob(job *types.Job, cs *ChallengeSet) error {
+	if cs.JobID != job.ID || cs.OutputRoot != job.OutputRoot {
+		return fmt.Errorf("%w: job %s", ErrChallengeMismatch, job.ID)
+	}
+	if job.OutputSize <= 0 {
+		return fmt.Errorf("%w: job %s has no committed output size", ErrEmptyOutput, job.ID)
+	}
+	if cs.OutputSize != job.OutputSize {
+		return fmt.Errorf("%w: output size %d, committed %d", ErrChallengeMismatch, cs.OutputSize, job.OutputSize)
+	}
+	return nil
+}
+
+// matchChallenge checks that a challenge set has the expected seed, strata
+// and indices
+func matchChallenge(expected, cs *ChallengeSet) error {
+	if cs.Seed != expected.Seed || cs.OutputSize != expected.OutputSize {
+		return fmt.Errorf("%w: seed or output size differs", ErrChallengeMismatch)
+	}
+	if len(expected.Strata) != len(cs.Strata) {
+		return fmt.Errorf("%w: expected %d strata, got %d", ErrChallengeMismatch, len(expected.Strata), len(cs.Strata))
+	}
+	for i := range expected.Strata {
+		if expected.Strata[i] != cs.Strata[i] {
+			return fmt.Errorf("%w: stratum %d differs", ErrChallengeMismatch, i)
+		}
+	}
+	if len(expected.Indices) != len(cs.Indices) {
+		return fmt.Errorf("%w: expected %d indices, got %d", ErrChallengeMismatch, len(expected.Indices), len(cs.Indices))
+	}
+	for i := range expected.Indices {
+		if expected.Indices[i] != cs.Indices[i] {
+			return fmt.Errorf("%w: index %d differs", ErrChallengeMismatch, i)
+		}
+	}
+	return nil
+}
+
+// challenge builds a challenge set, sampling uniformly when strata is empty
+func (s *Sampler) challenge(job *types.Job, outputSize int, seed common.Hash, strata []Stratum) (*ChallengeSet, error) {
+	if job.OutputRoot == (common.Hash{}) {
+		return nil, ErrNoOutputRoot
+	}
+	if outputSize <= 0 {
+		return nil, ErrEmptyOutput
+	}
+	if s.cfg.SampleSize <= 0 {
+		return nil, ErrInvalidSampleSize
+	}
+
+	cs := &ChallengeSet{
+		JobID:      job.ID,
+		OutputRoot: job.OutputRoot,
+		OutputSize: outputSize,
+		Seed:       seed,
+	}
+	stream := randomness.NewStream(challengeSeed(job, outputSize, seed))
+
+	if len(strata) == 0 {
+		cs.Indices = sampleUniform(stream, 0, outputSize, s.sampleSize(outputSize))
+		return cs, nil
+	}
+
+	ordered, err := normalizeStrata(strata, outputSize)
+	if err != nil {
+		return nil, err
+	}
+	cs.Strata = ordered
+	cs.Indices = sampleStratified(stream, ordered, s.sampleSize(outputSize))
+	return cs, nil
+}
+
+// strataCount picks how many chunk-range strata to use so that every
+// stratum can receive its minimum allocation within the sample size
+func (s *Sampler) strataCount(outputSize int) int {
+	n := s.sampleSize(outputSize) / DefaultMinPerStratum
+	if n < 1 {
+		n = 1
+	}
+	return minInt(n, DefaultStrataCount)
+}
+
+// sampleSize caps the configured sample size at the output size
+func (s *Sampler) sampleSize(outputSize int) int {
+	if s.cfg.SampleSize > outputSize {
+		return outputSize
+	}
+	return s.cfg.SampleSize
+}
+
+// challengeSeed binds the caller's seed to the job and its commitment so the
+// same seed cannot be replayed against a different output
+func challengeSeed(job *types.Job, outputSize int, seed common.Hash) common.Hash {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(outputSize))
+	return randomness.DeriveSeed(challengeDomain, seed.Bytes(), job.OutputRoot.Bytes(), []byte(job.ID), size[:])
+}
+
+// sampleUniform draws k distinct indices from [lo, hi) using Floyd's
+// algorithm and returns them sorted ascending
+func sampleUniform(stream *randomness.Stream, lo, hi, k int) []int {
+	n := hi - lo
+	if k > n {
+		k = n
+	}
+
+	chosen := make(map[int]struct{}, k)
+	indices := make([]int, 0, k)
+	for j := n - k; j < n; j++ {
+		t := stream.Intn(j + 1)
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		indices = append(indices, lo+t)
+	}
+
+	sort.Ints(indices)
+	return indices
+}
@@ -0,0 +1,109 @@
+package popc
+
+import (
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJob() *types.Job {
+	return &types.Job{
+		ID:         "job-popc",
+		Status:     types.JobStatusCommitted,
+		OutputRoot: common.HexToHash("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+	}
+}
+
+func TestSampler_Challenge(t *testing.T) {
+	cfg := config.DefaultConfig().PoPC
+	sampler := NewSampler(cfg)
+	seed := common.HexToHash("0x01")
+
+	cs, err := sampler.Challenge(testJob(), 100000, seed)
+	require.NoError(t, err)
+
+	assert.Equal(t, "job-popc", cs.JobID)
+	assert.Equal(t, cfg.SampleSize, len(cs.Indices))
+
+	seen := make(map[int]bool)
+	for i, idx := range cs.Indices {
+		assert.GreaterOrEqual(t, idx, 0)
+		assert.Less(t, idx, 100000)
+		assert.False(t, seen[idx], "duplicate index %d", idx)
+		seen[idx] = true
+		if i > 0 {
+			assert.Greater(t, idx, cs.Indices[i-1])
+		}
+	}
+}
+
+func TestSampler_Reproducible(t *testing.T) {
+	sampler := NewSampler(config.DefaultConfig().PoPC)
+	seed := common.HexToHash("0x02")
+
+	a, err := sampler.Challenge(testJob(), 50000, seed)
+	require.NoError(t, err)
+	b, err := sampler.Challenge(testJob(), 50000, seed)
+	require.NoError(t, err)
+	assert.Equal(t, a.Indices, b.Indices)
+
+	c, err := sampler.Challenge(testJob(), 50000, common.HexToHash("0x03"))
+	require.NoError(t, err)
+	assert.NotEqual(t, a.Indices, c.Indices)
+}
+
+func TestSampler_SmallOutput(t *testing.T) {
+	sampler := NewSampler(config.DefaultConfig().PoPC)
+
+	cs, err := sampler.Challenge(testJob(), 10, common.HexToHash("0x04"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, cs.Indices)
+}
+
+func TestSampler_Errors(t *testing.T) {
+	sampler := NewSampler(config.DefaultConfig().PoPC)
+	seed := common.HexToHash("0x05")
+
+	_, err := sampler.Challenge(&types.Job{ID: "no-root"}, 100, seed)
+	assert.ErrorIs(t, err, ErrNoOutputRoot)
+
+	_, err = sampler.Challenge(testJob(), 0, seed)
+	assert.ErrorIs(t, err, ErrEmptyOutput)
+
+	_, err = NewSampler(config.PoPCConfig{}).Challenge(testJob(), 100, seed)
+	assert.ErrorIs(t, err, ErrInvalidSampleSize)
+}
+
+func TestSampler_Verify(t *testing.T) {
+	sampler := NewSampler(config.DefaultConfig().PoPC)
+	job := testJob()
+	job.OutputSize = 20000
+
+	cs, err := sampler.Challenge(job, 20000, common.HexToHash("0x06"))
+	require.NoError(t, err)
+	assert.NoError(t, sampler.Verify(job, cs))
+
+	// Tampering with any index must be detected
+	cs.Indices[0]++
+	assert.ErrorIs(t, sampler.Verify(job, cs), ErrChallengeMismatch)
+
+	// A challenge for one job cannot be reused for another
+	other := testJob()
+	other.ID = "job-other"
+	other.OutputSize = 20000
+	assert.ErrorIs(t, sampler.Verify(other, cs), ErrChallengeMismatch)
+
+	// Nor for a different output size than the job committed
+	cs.Indices[0]--
+	assert.NoError(t, sampler.Verify(job, cs))
+	job.OutputSize = 30000
+	assert.ErrorIs(t, sampler.Verify(job, cs), ErrChallengeMismatch)
+
+	// A job without a committed size cannot take the prover's word for it
+	job.OutputSize = 0
+	assert.ErrorIs(t, sampler.Verify(job, cs), ErrEmptyOutput)
+}
@@ -0,0 +1,151 @@
+package popc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+)
+
+// Stratified sampling defaults
+const (
+	DefaultStrataCount   = 16 // chunk-range strata when the caller supplies none
+	DefaultMinPerStratum = 8  // samples every stratum receives at minimum
+)
+
+// ErrInvalidStrata is returned when strata do not exactly cover the output
+var ErrInvalidStrata = errors.New("popc: strata must cover the output without gaps or overlap")
+
+// Stratum is a labelled, contiguous range [Start, End) of output indices
+type Stratum struct {
+	Label string `json:"label"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// Size returns the number of output indices in the stratum
+func (s Stratum) Size() int {
+	return s.End - s.Start
+}
+
+// ChunkStrata partitions [0, outputSize) into at most n contiguous strata of
+// near-equal size
+func ChunkStrata(outputSize, n int) []Stratum {
+	if n > outputSize {
+		n = outputSize
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	strata := make([]Stratum, n)
+	for i := range strata {
+		strata[i] = Stratum{
+			Label: fmt.Sprintf("range-%d", i),
+			Start: i * outputSize / n,
+			End:   (i + 1) * outputSize / n,
+		}
+	}
+	return strata
+}
+
+// normalizeStrata sorts strata by start and checks that they partition
+// [0, outputSize)
+func normalizeStrata(strata []Stratum, outputSize int) ([]Stratum, error) {
+	if len(strata) == 0 {
+		return nil, ErrInvalidStrata
+	}
+
+	ordered := make([]Stratum, len(strata))
+	copy(ordered, strata)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Start < ordered[j].Start
+	})
+
+	next := 0
+	for _, st := range ordered {
+		if st.Start != next || st.End <= st.Start {
+			return nil, fmt.Errorf("%w: stratum %q [%d, %d)", ErrInvalidStrata, st.Label, st.Start, st.End)
+		}
+		next = st.End
+	}
+	if next != outputSize {
+		return nil, fmt.Errorf("%w: strata end at %d, output size is %d", ErrInvalidStrata, next, outputSize)
+	}
+
+	return ordered, nil
+}
+
+// sampleStratified draws samples from every stratum and returns the union
+// sorted ascending
+func sampleStratified(stream *randomness.Stream, strata []Stratum, k int) []int {
+	alloc := allocateSamples(strata, k)
+
+	indices := make([]int, 0, k)
+	for i, st := range strata {
+		indices = append(indices, sampleUniform(stream, st.Start, st.End, alloc[i])...)
+	}
+
+	sort.Ints(indices)
+	return indices
+}
+
+// allocateSamples splits k samples across strata: each stratum first gets
+// DefaultMinPerStratum (or its whole size if smaller), and the remainder is
+// shared in proportion to stratum size using the largest-remainder method.
+// If the minimums alone exceed k, every stratum still receives its minimum.
+func allocateSamples(strata []Stratum, k int) []int {
+	alloc := make([]int, len(strata))
+	total, spare := 0, 0
+	for i, st := range strata {
+		alloc[i] = minInt(DefaultMinPerStratum, st.Size())
+		total += alloc[i]
+		spare += st.Size() - alloc[i]
+	}
+
+	remaining := k - total
+	if remaining <= 0 || spare == 0 {
+		return alloc
+	}
+	if remaining > spare {
+		remaining = spare
+	}
+
+	type share struct {
+		index     int
+		remainder int
+	}
+	shares := make([]share, len(strata))
+	given := 0
+	for i, st := range strata {
+		capacity := st.Size() - alloc[i]
+		quota := remaining * capacity
+		alloc[i] += quota / spare
+		given += quota / spare
+		shares[i] = share{index: i, remainder: quota % spare}
+	}
+
+	// Hand out what integer division left over, largest remainder first
+	sort.SliceStable(shares, func(a, b int) bool {
+		return shares[a].remainder > shares[b].remainder
+	})
+	for _, sh := range shares {
+		if given >= remaining {
+			break
+		}
+		if alloc[sh.index] < strata[sh.index].Size() {
+			alloc[sh.index]++
+			given++
+		}
+	}
+
+	return alloc
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
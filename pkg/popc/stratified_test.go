@@ -0,0 +1,162 @@
+package popc
+
+import (
+	"testing"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countIn(indices []int, st Stratum) int {
+	n := 0
+	for _, idx := range indices {
+		if idx >= st.Start && idx < st.End {
+			n++
+		}
+	}
+	return n
+}
+
+func TestChunkStrata(t *testing.T) {
+	strata := ChunkStrata(100, 4)
+	require.Len(t, strata, 4)
+
+	assert.Equal(t, 0, strata[0].Start)
+	assert.Equal(t, 100, strata[3].End)
+	for i := 1; i < len(strata); i++ {
+		assert.Equal(t, strata[i-1].End, strata[i].Start)
+		assert.Equal(t, 25, strata[i].Size())
+	}
+
+	// Never more strata than output elements
+	assert.Len(t, ChunkStrata(3, 16), 3)
+}
+
+func TestSampler_StratifiedCoversEveryRange(t *testing.T) {
+	cfg := config.DefaultConfig().PoPC
+	cfg.StratifiedSampling = true
+	sampler := NewSampler(cfg)
+
+	job := testJob()
+	job.OutputSize = 1000000
+
+	cs, err := sampler.Challenge(job, 1000000, common.HexToHash("0x20"))
+	require.NoError(t, err)
+
+	require.Len(t, cs.Strata, DefaultStrataCount)
+	assert.Len(t, cs.Indices, cfg.SampleSize)
+
+	// Equal-size strata receive a near-equal share of the samples
+	for _, st := range cs.Strata {
+		n := countIn(cs.Indices, st)
+		assert.GreaterOrEqual(t, n, cfg.SampleSize/DefaultStrataCount)
+		assert.LessOrEqual(t, n, cfg.SampleSize/DefaultStrataCount+1)
+	}
+
+	assert.NoError(t, sampler.Verify(job, cs))
+}
+
+func TestSampler_UniformWhenDisabled(t *testing.T) {
+	cfg := config.DefaultConfig().PoPC
+	cfg.StratifiedSampling = false
+
+	cs, err := NewSampler(cfg).Challenge(testJob(), 1000000, common.HexToHash("0x21"))
+	require.NoError(t, err)
+
+	assert.Empty(t, cs.Strata)
+	assert.Len(t, cs.Indices, cfg.SampleSize)
+}
+
+func TestSampler_LabelledStrataMinimum(t *testing.T) {
+	cfg := config.DefaultConfig().PoPC
+	cfg.SampleSize = 100
+	sampler := NewSampler(cfg)
+
+	// A tiny tail stratum must still be sampled
+	strata := []Stratum{
+		{Label: "tail", Start: 99990, End: 100000},
+		{Label: "body", Start: 0, End: 99990},
+	}
+
+	job := testJob()
+	job.OutputSize = 100000
+
+	cs, err := sampler.ChallengeStrata(job, 100000, common.HexToHash("0x22"), strata)
+	require.NoError(t, err)
+
+	assert.Len(t, cs.Indices, 100)
+	assert.Equal(t, "body", cs.Strata[0].Label)
+	assert.Equal(t, DefaultMinPerStratum, countIn(cs.Indices, cs.Strata[1]))
+	assert.NoError(t, sampler.VerifyStrata(job, cs, strata))
+	assert.ErrorIs(t, sampler.VerifyStrata(job, cs, nil), ErrInvalidStrata)
+
+	// Labelled strata are only accepted against the verifier's own strata
+	assert.ErrorIs(t, sampler.Verify(job, cs), ErrChallengeMismatch)
+}
+
+func TestSampler_VerifyIgnoresProverStrata(t *testing.T) {
+	cfg := config.DefaultConfig().PoPC
+	cfg.SampleSize = 100
+	sampler := NewSampler(cfg)
+	job := testJob()
+	job.OutputSize = 100000
+
+	// A set built over strata the prover picked is rejected even though it
+	// is reproducible from its own strata field
+	steered, err := sampler.ChallengeStrata(job, 100000, common.HexToHash("0x24"), []Stratum{
+		{Label: "easy", Start: 0, End: 99000},
+		{Label: "rest", Start: 99000, End: 100000},
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, sampler.Verify(job, steered), ErrChallengeMismatch)
+
+	// Dropping the strata does not fall back to uniform sampling
+	uniform, err := NewSampler(config.PoPCConfig{SampleSize: 100}).Challenge(job, 100000, common.HexToHash("0x24"))
+	require.NoError(t, err)
+	require.Empty(t, uniform.Strata)
+	assert.ErrorIs(t, sampler.Verify(job, uniform), ErrChallengeMismatch)
+}
+
+func TestSampler_InvalidStrata(t *testing.T) {
+	sampler := NewSampler(config.DefaultConfig().PoPC)
+	seed := common.HexToHash("0x23")
+
+	tests := []struct {
+		name   string
+		strata []Stratum
+	}{
+		{"Empty", nil},
+		{"Gap", []Stratum{{Start: 0, End: 40}, {Start: 50, End: 100}}},
+		{"Overlap", []Stratum{{Start: 0, End: 60}, {Start: 50, End: 100}}},
+		{"Short", []Stratum{{Start: 0, End: 90}}},
+		{"EmptyRange", []Stratum{{Start: 0, End: 0}, {Start: 0, End: 100}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sampler.ChallengeStrata(testJob(), 100, seed, tt.strata)
+			assert.ErrorIs(t, err, ErrInvalidStrata)
+		})
+	}
+}
+
+func TestAllocateSamples(t *testing.T) {
+	strata := []Stratum{
+		{Start: 0, End: 5},
+		{Start: 5, End: 505},
+		{Start: 505, End: 1505},
+	}
+
+	alloc := allocateSamples(strata, 100)
+	assert.Equal(t, 5, alloc[0]) // smaller than the minimum: fully sampled
+	assert.Equal(t, 100, alloc[0]+alloc[1]+alloc[2])
+	assert.Greater(t, alloc[2], alloc[1])
+
+	// Minimums win over the sample size
+	alloc = allocateSamples(ChunkStrata(1000, 20), 50)
+	for _, a := range alloc {
+		assert.Equal(t, DefaultMinPerStratum, a)
+	}
+}
@@ -0,0 +1,224 @@
+package popc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrUnknownValidator is returned for votes from unregistered validators
+	ErrUnknownValidator = errors.New("popc: unknown validator")
+	// ErrValidatorInactive is returned for votes from validators that are not active
+	ErrValidatorInactive = errors.New("popc: validator is not active")
+	// ErrDuplicateVote is returned when a validator votes twice on a job
+	ErrDuplicateVote = errors.New("popc: validator already voted on job")
+	// ErrNoVotes is returned when tallying a job nobody voted on
+	ErrNoVotes = errors.New("popc: no votes for job")
+	// ErrBallotResolved is returned when voting on a job whose truth is known
+	ErrBallotResolved = errors.New("popc: job outcome already resolved")
+)
+
+// Vote is a validator's pass/fail judgement of a job's PoPC result
+type Vote struct {
+	JobID     string         `json:"job_id"`
+	Validator common.Address `json:"validator"`
+	Pass      bool           `json:"pass"`
+	CastAt    time.Time      `json:"cast_at"`
+}
+
+// VoteTally is the stake-weighted result of the votes on a job
+type VoteTally struct {
+	JobID     string   `json:"job_id"`
+	PassStake *big.Int `json:"pass_stake"`
+	FailStake *big.Int `json:"fail_stake"`
+	Votes     int      `json:"votes"`
+	Outcome   Verdict  `json:"outcome"` // pass needs at least 2/3 of the voting stake
+}
+
+type ballot struct {
+	votes    map[common.Address]Vote
+	resolved bool
+	valid    bool // ground truth, once resolved
+}
+
+// VoteAggregator collects validator votes on PoPC results and, once a job's
+// truth is known, updates each voter's TotalVotes, CorrectVotes and FalsePass
+type VoteAggregator struct {
+	mu         sync.Mutex
+	validators map[common.Address]*types.Validator
+	ballots    map[string]*ballot
+}
+
+// NewVoteAggregator creates an empty vote aggregator
+func NewVoteAggregator() *VoteAggregator {
+	return &VoteAggregator{
+		validators: make(map[common.Address]*types.Validator),
+		ballots:    make(map[string]*ballot),
+	}
+}
+
+// Register adds a validator whose counters the aggregator maintains
+func (a *VoteAggregator) Register(v *types.Validator) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.validators[v.Address] = v
+}
+
+// Cast records a vote
+func (a *VoteAggregator) Cast(vote Vote) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	v, ok := a.validators[vote.Validator]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownValidator, vote.Validator.Hex())
+	}
+	if v.Status != types.ValidatorStatusActive {
+		return fmt.Errorf("%w: %s is %s", ErrValidatorInactive, vote.Validator.Hex(), v.Status)
+	}
+
+	b := a.ballotFor(vote.JobID)
+	if b.resolved {
+		return fmt.Errorf("%w: %s", ErrBallotResolved, vote.JobID)
+	}
+	if _, ok := b.votes[vote.Validator]; ok {
+		return fmt.Errorf("%w: %s on %s", ErrDuplicateVote, vote.Validator.Hex(), vote.JobID)
+	}
+
+	b.votes[vote.Validator] = vote
+	return nil
+}
+
+// Tally computes the stake-weighted outcome of the votes on a job
+func (a *VoteAggregator) Tally(jobID string) (*VoteTally, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b, ok := a.ballots[jobID]
+	if !ok || len(b.votes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoVotes, jobID)
+	}
+
+	tally := &VoteTally{
+		JobID:     jobID,
+		PassStake: new(big.Int),
+		FailStake: new(big.Int),
+		Votes:     len(b.votes),
+	}
+	for addr, vote := range b.votes {
+		stake := a.validators[addr].Stake
+		if stake == nil {
+			continue
+		}
+		if vote.Pass {
+			tally.PassStake.Add(tally.PassStake, stake)
+		} else {
+			tally.FailStake.Add(tally.FailStake, stake)
+		}
+	}
+
+	// pass*3 >= (pass+fail)*2
+	total := new(big.Int).Add(tally.PassStake, tally.FailStake)
+	lhs := new(big.Int).Mul(tally.PassStake, big.NewInt(3))
+	rhs := new(big.Int).Mul(total, big.NewInt(2))
+	if total.Sign() > 0 && lhs.Cmp(rhs) >= 0 {
+		tally.Outcome = VerdictPass
+	} else {
+		tally.Outcome = VerdictFail
+	}
+
+	return tally, nil
+}
+
+// Resolve records whether the job's result was actually valid and updates
+// the counters of every validator that voted on it. Resolving again with a
+// different truth, e.g. after a late fraud proof, reverses the earlier
+// accounting before applying the new one.
+func (a *VoteAggregator) Resolve(jobID string, valid bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b, ok := a.ballots[jobID]
+	if !ok || len(b.votes) == 0 {
+		return fmt.Errorf("%w: %s", ErrNoVotes, jobID)
+	}
+
+	if b.resolved {
+		if b.valid == valid {
+			return nil
+		}
+		a.account(b, -1)
+	}
+
+	b.resolved = true
+	b.valid = valid
+	a.account(b, 1)
+	return nil
+}
+
+// ResolveSettlement resolves a job from its fraud-window settlement
+func (a *VoteAggregator) ResolveSettlement(s Settlement) error {
+	return a.Resolve(s.JobID, s.Status == types.JobStatusCompleted)
+}
+
+// Voters returns the addresses that voted on a job, sorted
+func (a *VoteAggregator) Voters(jobID string) []common.Address {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b, ok := a.ballots[jobID]
+	if !ok {
+		return nil
+	}
+
+	voters := make([]common.Address, 0, len(b.votes))
+	for addr := range b.votes {
+		voters = append(voters, addr)
+	}
+	sort.Slice(voters, func(i, j int) bool {
+		return voters[i].Hex() < voters[j].Hex()
+	})
+	return voters
+}
+
+// ValidatorAccuracy returns the share of a validator's votes that matched
+// the resolved truth
+func ValidatorAccuracy(v *types.Validator) float64 {
+	if v.TotalVotes == 0 {
+		return 0
+	}
+	return float64(v.CorrectVotes) / float64(v.TotalVotes)
+}
+
+// account applies (sign=1) or reverses (sign=-1) a resolved ballot's effect
+// on validator counters
+func (a *VoteAggregator) account(b *ballot, sign int) {
+	for addr, vote := range b.votes {
+		v := a.validators[addr]
+		v.TotalVotes += sign
+		if vote.Pass == b.valid {
+			v.CorrectVotes += sign
+		}
+		if vote.Pass && !b.valid {
+			v.FalsePass += sign
+		}
+	}
+}
+
+// ballotFor returns the ballot for a job, creating it if needed
+func (a *VoteAggregator) ballotFor(jobID string) *ballot {
+	b, ok := a.ballots[jobID]
+	if !ok {
+		b = &ballot{votes: make(map[common.Address]Vote)}
+		a.ballots[jobID] = b
+	}
+	return b
+}
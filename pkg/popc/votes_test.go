@@ -0,0 +1,136 @@
+package popc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidator(addr string, stake int64) *types.Validator {
+	return &types.Validator{
+		Address: common.HexToAddress(addr),
+		Stake:   big.NewInt(stake),
+		Status:  types.ValidatorStatusActive,
+	}
+}
+
+func castAll(t *testing.T, a *VoteAggregator, jobID string, votes map[*types.Validator]bool) {
+	for v, pass := range votes {
+		require.NoError(t, a.Cast(Vote{JobID: jobID, Validator: v.Address, Pass: pass, CastAt: time.Now()}))
+	}
+}
+
+func TestVoteAggregator_Tally(t *testing.T) {
+	a := NewVoteAggregator()
+	v1 := testValidator("0x01", 100)
+	v2 := testValidator("0x02", 100)
+	v3 := testValidator("0x03", 50)
+	for _, v := range []*types.Validator{v1, v2, v3} {
+		a.Register(v)
+	}
+
+	castAll(t, a, "job-1", map[*types.Validator]bool{v1: true, v2: true, v3: false})
+	tally, err := a.Tally("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictPass, tally.Outcome) // 200 of 250 >= 2/3
+	assert.Equal(t, big.NewInt(200), tally.PassStake)
+	assert.Equal(t, 3, tally.Votes)
+
+	castAll(t, a, "job-2", map[*types.Validator]bool{v1: true, v2: false, v3: true})
+	tally, err = a.Tally("job-2")
+	require.NoError(t, err)
+	assert.Equal(t, VerdictFail, tally.Outcome) // 150 of 250 < 2/3
+
+	_, err = a.Tally("job-none")
+	assert.ErrorIs(t, err, ErrNoVotes)
+}
+
+func TestVoteAggregator_CastErrors(t *testing.T) {
+	a := NewVoteAggregator()
+	v := testValidator("0x01", 100)
+	jailed := testValidator("0x02", 100)
+	jailed.Status = types.ValidatorStatusJailed
+	a.Register(v)
+	a.Register(jailed)
+
+	assert.ErrorIs(t, a.Cast(Vote{JobID: "job", Validator: common.HexToAddress("0x09")}), ErrUnknownValidator)
+	assert.ErrorIs(t, a.Cast(Vote{JobID: "job", Validator: jailed.Address}), ErrValidatorInactive)
+
+	require.NoError(t, a.Cast(Vote{JobID: "job", Validator: v.Address, Pass: true}))
+	assert.ErrorIs(t, a.Cast(Vote{JobID: "job", Validator: v.Address, Pass: false}), ErrDuplicateVote)
+
+	require.NoError(t, a.Resolve("job", true))
+	late := testValidator("0x03", 100)
+	a.Register(late)
+	assert.ErrorIs(t, a.Cast(Vote{JobID: "job", Validator: late.Address}), ErrBallotResolved)
+}
+
+func TestVoteAggregator_ResolveUpdatesCounters(t *testing.T) {
+	a := NewVoteAggregator()
+	honest := testValidator("0x01", 100)
+	lazy := testValidator("0x02", 100)
+	a.Register(honest)
+	a.Register(lazy)
+
+	castAll(t, a, "job-bad", map[*types.Validator]bool{honest: false, lazy: true})
+	require.NoError(t, a.Resolve("job-bad", false))
+
+	assert.Equal(t, 1, honest.TotalVotes)
+	assert.Equal(t, 1, honest.CorrectVotes)
+	assert.Equal(t, 0, honest.FalsePass)
+
+	assert.Equal(t, 1, lazy.TotalVotes)
+	assert.Equal(t, 0, lazy.CorrectVotes)
+	assert.Equal(t, 1, lazy.FalsePass)
+
+	// Resolving again with the same truth is a no-op
+	require.NoError(t, a.Resolve("job-bad", false))
+	assert.Equal(t, 1, lazy.TotalVotes)
+
+	assert.Equal(t, 1.0, ValidatorAccuracy(honest))
+	assert.Equal(t, 0.0, ValidatorAccuracy(lazy))
+	assert.Equal(t, 0.0, ValidatorAccuracy(&types.Validator{}))
+}
+
+func TestVoteAggregator_LateFraudProofFlipsAccounting(t *testing.T) {
+	a := NewVoteAggregator()
+	passer := testValidator("0x01", 100)
+	failer := testValidator("0x02", 100)
+	a.Register(passer)
+	a.Register(failer)
+
+	castAll(t, a, "job-late", map[*types.Validator]bool{passer: true, failer: false})
+
+	// Initially believed valid
+	require.NoError(t, a.Resolve("job-late", true))
+	assert.Equal(t, 1, passer.CorrectVotes)
+	assert.Equal(t, 0, failer.CorrectVotes)
+
+	// A fraud proof then slashes the job
+	require.NoError(t, a.ResolveSettlement(Settlement{JobID: "job-late", Status: types.JobStatusSlashed}))
+
+	assert.Equal(t, 1, passer.TotalVotes)
+	assert.Equal(t, 0, passer.CorrectVotes)
+	assert.Equal(t, 1, passer.FalsePass)
+
+	assert.Equal(t, 1, failer.TotalVotes)
+	assert.Equal(t, 1, failer.CorrectVotes)
+	assert.Equal(t, 0, failer.FalsePass)
+}
+
+func TestVoteAggregator_Voters(t *testing.T) {
+	a := NewVoteAggregator()
+	v2 := testValidator("0x02", 1)
+	v1 := testValidator("0x01", 1)
+	a.Register(v1)
+	a.Register(v2)
+	castAll(t, a, "job", map[*types.Validator]bool{v1: true, v2: true})
+
+	assert.Equal(t, []common.Address{v1.Address, v2.Address}, a.Voters("job"))
+	assert.Nil(t, a.Voters("missing"))
+}
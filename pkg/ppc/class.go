@@ -0,0 +1,193 @@
+package ppc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+)
+
+// Class labels used when a job leaves a dimension unspecified
+const (
+	ClassCPU       = "cpu" // GPU label for jobs that need no GPU
+	ClassAnyRegion = "any" // Region label for jobs with no region preference
+)
+
+// vramBands are the upper bounds, in GB, of the VRAM bands. Jobs needing
+// more than the last bound share an open-ended band.
+var vramBands = []int{16, 24, 48, 80}
+
+// ResourceClass groups jobs that compete for the same kind of capacity and
+// therefore share a posted price
+type ResourceClass struct {
+	GPU      string `json:"gpu"`       // Normalized GPU model, or "cpu"
+	VRAMBand string `json:"vram_band"` // e.g. "vram-24", "vram-80+"
+	Region   string `json:"region"`    // Lowercased region, or "any"
+}
+
+// ClassOf derives a job's resource class from its specs
+func ClassOf(spec types.JobSpecs) ResourceClass {
+	gpu := types.NormalizeGPU(spec.GPU)
+	if gpu == "" {
+		gpu = ClassCPU
+	}
+	region := strings.ToLower(strings.TrimSpace(spec.Region))
+	if region == "" {
+		region = ClassAnyRegion
+	}
+	return ResourceClass{GPU: gpu, VRAMBand: vramBand(spec.VRAM), Region: region}
+}
+
+// String returns the class as gpu/vram-band/region
+func (c ResourceClass) String() string {
+	return c.GPU + "/" + c.VRAMBand + "/" + c.Region
+}
+
+// vramBand returns the label of the smallest band that fits vram GB
+func vramBand(vram int) string {
+	if vram <= 0 {
+		return "vram-0"
+	}
+	for _, bound := range vramBands {
+		if vram <= bound {
+			return fmt.Sprintf("vram-%d", bound)
+		}
+	}
+	return fmt.Sprintf("vram-%d+", vramBands[len(vramBands)-1])
+}
+
+// PriceBook keeps a separate controller, and so a separate posted price,
+// for every resource class. A class gets its controller the first time it
+// is observed through Adjust; until then it is priced at the book's
+// starting price and nothing is stored for it.
+type PriceBook struct {
+	mu          sync.Mutex
+	cfg         config.PPCConfig
+	initial     float64 // Starting price, within the configured bounds
+	controllers map[ResourceClass]*Controller
+	history     *PriceHistory
+}
+
+// NewPriceBook creates a price book whose classes start at initial, clamped
+// to [p_min, p_max]. Pass PPCConfig.MinPrice to start every class at p_min.
+func NewPriceBook(cfg config.PPCConfig, initial float64) (*PriceBook, error) {
+	// Validate once up front so per-class construction cannot fail
+	c, err := NewController(cfg, initial)
+	if err != nil {
+		return nil, err
+	}
+	return &PriceBook{
+		cfg:         cfg,
+		initial:     c.Price(),
+		controllers: make(map[ResourceClass]*Controller),
+	}, nil
+}
+
+// SetHistory records every class's price adjustments to h
+func (b *PriceBook) SetHistory(h *PriceHistory) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.history = h
+}
+
+// Controller returns the controller for a class, or false if the class has
+// never been adjusted
+func (b *PriceBook) Controller(class ResourceClass) (*Controller, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.controllers[class]
+	return c, ok
+}
+
+// Price returns the posted price for a class. A class that has never been
+// adjusted is at the book's starting price.
+func (b *PriceBook) Price(class ResourceClass) float64 {
+	if c, ok := b.Controller(class); ok {
+		return c.Price()
+	}
+	return b.initial
+}
+
+// PriceFor returns a job's resource class and its posted price
+func (b *PriceBook) PriceFor(spec types.JobSpecs) (ResourceClass, float64) {
+	class := ClassOf(spec)
+	return class, b.Price(class)
+}
+
+// Adjust applies an observation of one class's utilization and queue time
+// to that class's price only
+func (b *PriceBook) Adjust(class ResourceClass, obs Observation) (float64, bool, error) {
+	c, err := b.controller(class, obs)
+	if err != nil {
+		return b.Price(class), false, fmt.Errorf("class %s: %w", class, err)
+	}
+	price, adjusted, err := c.Adjust(obs)
+	if err != nil {
+		return price, false, fmt.Errorf("class %s: %w", class, err)
+	}
+
+	b.mu.Lock()
+	history := b.history
+	b.mu.Unlock()
+	if adjusted && history != nil {
+		history.Record(PricePoint{
+			At:          obs.At,
+			Class:       class,
+			Price:       price,
+			Utilization: obs.Utilization,
+			QueueTime:   obs.QueueTime,
+		})
+	}
+	return price, adjusted, nil
+}
+
+// controller returns the controller for a class. A new class's controller
+// is only stored once it accepts obs, so a rejected first observation does
+// not add the class to the book.
+func (b *PriceBook) controller(class ResourceClass, obs Observation) (*Controller, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.controllers[class]; ok {
+		return c, nil
+	}
+	c, err := NewController(b.cfg, b.initial)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Next(obs); err != nil {
+		return nil, err
+	}
+	b.controllers[class] = c
+	return c, nil
+}
+
+// Classes returns every class the book has adjusted, sorted by name
+func (b *PriceBook) Classes() []ResourceClass {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	classes := make([]ResourceClass, 0, len(b.controllers))
+	for c := range b.controllers {
+		classes = append(classes, c)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].String() < classes[j].String()
+	})
+	return classes
+}
+
+// Prices returns a snapshot of every class's posted price
+func (b *PriceBook) Prices() map[ResourceClass]float64 {
+	classes := b.Classes()
+	prices := make(map[ResourceClass]float64, len(classes))
+	for _, c := range classes {
+		prices[c] = b.Price(c)
+	}
+	return prices
+}
@@ -0,0 +1,110 @@
+package ppc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassOf(t *testing.T) {
+	tests := []struct {
+		name string
+		spec types.JobSpecs
+		want string
+	}{
+		{"cpu only", types.JobSpecs{}, "cpu/vram-0/any"},
+		{"vendor prefix dropped", types.JobSpecs{GPU: "NVIDIA H100", VRAM: 80, Region: "US-East"}, "h100/vram-80/us-east"},
+		{"band rounds up", types.JobSpecs{GPU: "RTX 4090", VRAM: 20, Region: "eu"}, "rtx 4090/vram-24/eu"},
+		{"small band", types.JobSpecs{GPU: "T4", VRAM: 8}, "t4/vram-16/any"},
+		{"open band", types.JobSpecs{GPU: "B200", VRAM: 192}, "b200/vram-80+/any"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassOf(tt.spec).String())
+		})
+	}
+
+	assert.Equal(t, ClassOf(types.JobSpecs{GPU: "NVIDIA RTX 4090", VRAM: 24}), ClassOf(types.JobSpecs{GPU: "rtx 4090", VRAM: 24}))
+}
+
+func TestPriceBook_ClassesPriceIndependently(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	book, err := NewPriceBook(cfg, 1)
+	require.NoError(t, err)
+
+	h100 := ClassOf(types.JobSpecs{GPU: "H100", VRAM: 80, Region: "us-east"})
+	cpu := ClassOf(types.JobSpecs{})
+	at := time.Unix(1_700_000_000, 0)
+
+	for i := 0; i < 10; i++ {
+		_, _, err := book.Adjust(h100, Observation{Utilization: 1, QueueTime: 5 * cfg.TargetQueueTime, At: at})
+		require.NoError(t, err)
+		_, _, err = book.Adjust(cpu, Observation{Utilization: 0.1, At: at})
+		require.NoError(t, err)
+		at = at.Add(cfg.AdjustmentInterval)
+	}
+
+	assert.Greater(t, book.Price(h100), 1.0)
+	assert.Less(t, book.Price(cpu), 1.0)
+	assert.Equal(t, []ResourceClass{cpu, h100}, book.Classes())
+
+	class, price := book.PriceFor(types.JobSpecs{GPU: "NVIDIA H100", VRAM: 64, Region: "US-EAST"})
+	assert.Equal(t, h100, class)
+	assert.Equal(t, book.Price(h100), price)
+
+	prices := book.Prices()
+	assert.Len(t, prices, 2)
+	assert.Equal(t, book.Price(cpu), prices[cpu])
+}
+
+func TestPriceBook_BoundsAndErrors(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	_, err := NewPriceBook(cfg, 0)
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+
+	book, err := NewPriceBook(cfg, 1000)
+	require.NoError(t, err)
+	class := ClassOf(types.JobSpecs{GPU: "A100", VRAM: 40})
+	assert.Equal(t, cfg.MaxPrice, book.Price(class))
+
+	_, _, err = book.Adjust(class, Observation{QueueTime: -1})
+	assert.ErrorIs(t, err, ErrInvalidObservation)
+	assert.Contains(t, err.Error(), "a100/vram-48/any")
+
+	// A rejected observation does not add the class to the book
+	assert.Empty(t, book.Classes())
+	assert.Empty(t, book.Prices())
+	_, ok := book.Controller(class)
+	assert.False(t, ok)
+}
+
+func TestPriceBook_UnknownClassesAreNotStored(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	book, err := NewPriceBook(cfg, cfg.MinPrice)
+	require.NoError(t, err)
+
+	// Pricing and quoting arbitrary classes leaves the book empty
+	for _, spec := range []types.JobSpecs{{GPU: "H100", VRAM: 80}, {GPU: "made-up gpu", Region: "nowhere"}, {}} {
+		assert.Equal(t, cfg.MinPrice, book.Price(ClassOf(spec)))
+		_, err := book.Quote(spec, types.SLA{}, time.Hour)
+		require.NoError(t, err)
+	}
+	_, ok := book.Controller(ClassOf(types.JobSpecs{}))
+	assert.False(t, ok)
+	assert.Empty(t, book.Classes())
+	assert.Empty(t, book.Prices())
+
+	// Only an adjustment creates a controller
+	cpu := ClassOf(types.JobSpecs{})
+	_, _, err = book.Adjust(cpu, Observation{Utilization: 0.5, At: time.Unix(1_700_000_000, 0)})
+	require.NoError(t, err)
+	c, ok := book.Controller(cpu)
+	require.True(t, ok)
+	assert.Equal(t, c.Price(), book.Price(cpu))
+	assert.Equal(t, []ResourceClass{cpu}, book.Classes())
+}
@@ -0,0 +1,139 @@
+// Package ppc implements the Posted Price Controller that sets compute prices
+// from observed utilization and queue time
+package ppc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+)
+
+var (
+	// ErrInvalidObservation is returned for a negative or non-finite utilization or queue time
+	ErrInvalidObservation = errors.New("ppc: utilization and queue time must be finite and not negative")
+	// ErrInvalidPrice is returned when a price is not positive and finite
+	ErrInvalidPrice = errors.New("ppc: price must be positive and finite")
+)
+
+// Observation is the market state measured over one adjustment interval
+type Observation struct {
+	Utilization float64   `json:"utilization"` // Fraction of capacity in use, 0.0 to 1.0
+	QueueTime   float64   `json:"queue_time"`  // Average time jobs waited, in seconds
+	At          time.Time `json:"at"`
+}
+
+// Controller holds the posted price and moves it toward the utilization and
+// queue time targets. Each adjustment multiplies the price by
+//
+//	exp(α·(util − util*) + β·(q − q*)/q*)
+//
+// and clamps the result to [MinPrice, MaxPrice], so sustained congestion
+// raises the price geometrically and idle capacity lowers it.
+type Controller struct {
+	mu         sync.Mutex
+	cfg        config.PPCConfig
+	price      float64
+	lastAdjust time.Time
+}
+
+// NewController creates a controller posting the given starting price,
+// clamped to the configured bounds
+func NewController(cfg config.PPCConfig, initial float64) (*Controller, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("ppc: %w", err)
+	}
+	if !validPrice(initial) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrice, initial)
+	}
+	return &Controller{
+		cfg:   cfg,
+		price: clamp(initial, cfg.MinPrice, cfg.MaxPrice),
+	}, nil
+}
+
+// Config returns the controller's PPC configuration
+func (c *Controller) Config() config.PPCConfig {
+	return c.cfg
+}
+
+// Price returns the current posted price
+func (c *Controller) Price() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.price
+}
+
+// Next returns the price that would follow the current one for an
+// observation, without changing it
+func (c *Controller) Next(obs Observation) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.next(c.price, obs)
+}
+
+// Adjust applies an observation if at least AdjustmentInterval has passed
+// since the last adjustment, and returns the posted price and whether it was
+// adjusted. The first observation is always applied.
+func (c *Controller) Adjust(obs Observation) (float64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.lastAdjust.IsZero() && obs.At.Sub(c.lastAdjust) < c.cfg.AdjustmentInterval {
+		return c.price, false, nil
+	}
+
+	price, err := c.next(c.price, obs)
+	if err != nil {
+		return c.price, false, err
+	}
+	c.price = price
+	c.lastAdjust = obs.At
+	return price, true, nil
+}
+
+// Run adjusts the price every AdjustmentInterval using observations from
+// observe until ctx is cancelled. An invalid observation stops the loop.
+func (c *Controller) Run(ctx context.Context, observe func(now time.Time) Observation) error {
+	ticker := time.NewTicker(c.cfg.AdjustmentInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			if _, _, err := c.Adjust(observe(now)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// next computes the price following p for an observation
+func (c *Controller) next(p float64, obs Observation) (float64, error) {
+	if !(obs.Utilization >= 0) || !(obs.QueueTime >= 0) || math.IsInf(obs.Utilization, 1) || math.IsInf(obs.QueueTime, 1) {
+		return 0, fmt.Errorf("%w: utilization %v, queue time %v", ErrInvalidObservation, obs.Utilization, obs.QueueTime)
+	}
+
+	util := math.Min(obs.Utilization, 1)
+	utilErr := util - c.cfg.TargetUtilization
+	queueErr := (obs.QueueTime - c.cfg.TargetQueueTime) / c.cfg.TargetQueueTime
+
+	return clamp(p*math.Exp(c.cfg.Alpha*utilErr+c.cfg.Beta*queueErr), c.cfg.MinPrice, c.cfg.MaxPrice), nil
+}
+
+// validPrice reports whether p is positive and finite
+func validPrice(p float64) bool {
+	return p > 0 && !math.IsNaN(p) && !math.IsInf(p, 0)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,128 @@
+package ppc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newController(t *testing.T, initial float64) *Controller {
+	c, err := NewController(config.DefaultConfig().PPC, initial)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewController(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+
+	c, err := NewController(cfg, 100)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.MaxPrice, c.Price())
+
+	for _, p := range []float64{0, -1, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = NewController(cfg, p)
+		assert.ErrorIs(t, err, ErrInvalidPrice, "price %v", p)
+	}
+
+	cfg.MaxPrice = cfg.MinPrice / 2
+	_, err = NewController(cfg, 1)
+	assert.Error(t, err)
+}
+
+func TestController_Next(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	tests := []struct {
+		name string
+		obs  Observation
+		want float64
+	}{
+		{"on target holds", Observation{Utilization: cfg.TargetUtilization, QueueTime: cfg.TargetQueueTime}, 1},
+		{"busy raises", Observation{Utilization: 1, QueueTime: cfg.TargetQueueTime}, math.Exp(cfg.Alpha * 0.3)},
+		{"idle lowers", Observation{Utilization: 0.2, QueueTime: 0}, math.Exp(cfg.Alpha*-0.5 + cfg.Beta*-1)},
+		{"long queue raises", Observation{Utilization: cfg.TargetUtilization, QueueTime: 3 * cfg.TargetQueueTime}, math.Exp(cfg.Beta * 2)},
+		{"utilization above one is capped", Observation{Utilization: 5, QueueTime: cfg.TargetQueueTime}, math.Exp(cfg.Alpha * 0.3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newController(t, 1)
+			got, err := c.Next(tt.obs)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-12)
+			assert.Equal(t, 1.0, c.Price(), "Next must not change the price")
+		})
+	}
+
+	for _, obs := range []Observation{
+		{Utilization: -0.1},
+		{Utilization: math.NaN()},
+		{QueueTime: math.NaN()},
+		{QueueTime: math.Inf(1)},
+	} {
+		_, err := newController(t, 1).Next(obs)
+		assert.ErrorIs(t, err, ErrInvalidObservation, "%+v", obs)
+	}
+}
+
+func TestController_AdjustRespectsInterval(t *testing.T) {
+	c := newController(t, 1)
+	interval := c.Config().AdjustmentInterval
+	start := time.Unix(1_700_000_000, 0)
+	busy := func(at time.Time) Observation { return Observation{Utilization: 1, QueueTime: 120, At: at} }
+
+	p1, adjusted, err := c.Adjust(busy(start))
+	require.NoError(t, err)
+	assert.True(t, adjusted)
+	assert.Greater(t, p1, 1.0)
+
+	p2, adjusted, err := c.Adjust(busy(start.Add(interval / 2)))
+	require.NoError(t, err)
+	assert.False(t, adjusted)
+	assert.Equal(t, p1, p2)
+
+	p3, adjusted, err := c.Adjust(busy(start.Add(interval)))
+	require.NoError(t, err)
+	assert.True(t, adjusted)
+	assert.Greater(t, p3, p1)
+}
+
+func TestController_ClampsToBounds(t *testing.T) {
+	c := newController(t, 1)
+	cfg := c.Config()
+	at := time.Unix(1_700_000_000, 0)
+
+	for i := 0; i < 500; i++ {
+		_, _, err := c.Adjust(Observation{Utilization: 1, QueueTime: 10 * cfg.TargetQueueTime, At: at})
+		require.NoError(t, err)
+		at = at.Add(cfg.AdjustmentInterval)
+	}
+	assert.Equal(t, cfg.MaxPrice, c.Price())
+
+	for i := 0; i < 2000; i++ {
+		_, _, err := c.Adjust(Observation{At: at})
+		require.NoError(t, err)
+		at = at.Add(cfg.AdjustmentInterval)
+	}
+	assert.Equal(t, cfg.MinPrice, c.Price())
+}
+
+func TestController_Run(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	cfg.AdjustmentInterval = time.Millisecond
+	c, err := NewController(cfg, 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = c.Run(ctx, func(now time.Time) Observation {
+		return Observation{Utilization: 1, QueueTime: cfg.TargetQueueTime, At: now}
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Greater(t, c.Price(), 1.0)
+}
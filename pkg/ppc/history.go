@@ -0,0 +1,89 @@
+package ppc
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PricePoint is one price adjustment and the observation that caused it
+type PricePoint struct {
+	At          time.Time     `json:"at"`
+	Class       ResourceClass `json:"class"` // Zero for a single-class controller
+	Price       float64       `json:"price"`
+	Utilization float64       `json:"utilization"`
+	QueueTime   float64       `json:"queue_time"`
+}
+
+// PriceHistory records price adjustments, keeping at most limit points
+type PriceHistory struct {
+	mu     sync.Mutex
+	limit  int
+	points []PricePoint
+}
+
+// NewPriceHistory creates a history that keeps the newest limit points, or
+// every point if limit is 0
+func NewPriceHistory(limit int) *PriceHistory {
+	return &PriceHistory{limit: limit}
+}
+
+// Record appends a point, dropping the oldest if the history is full
+func (h *PriceHistory) Record(p PricePoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.points = append(h.points, p)
+	if h.limit > 0 && len(h.points) > h.limit {
+		h.points = append(h.points[:0:0], h.points[len(h.points)-h.limit:]...)
+	}
+}
+
+// Points returns every recorded point, oldest first
+func (h *PriceHistory) Points() []PricePoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]PricePoint(nil), h.points...)
+}
+
+// Class returns the recorded points for one class, oldest first
+func (h *PriceHistory) Class(class ResourceClass) []PricePoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var points []PricePoint
+	for _, p := range h.points {
+		if p.Class == class {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// WriteCSV writes the history as CSV with a header row
+func (h *PriceHistory) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"at", "class", "price", "utilization", "queue_time"}); err != nil {
+		return err
+	}
+	for _, p := range h.Points() {
+		class := ""
+		if p.Class != (ResourceClass{}) {
+			class = p.Class.String()
+		}
+		if err := cw.Write([]string{
+			p.At.UTC().Format(time.RFC3339),
+			class,
+			strconv.FormatFloat(p.Price, 'g', -1, 64),
+			strconv.FormatFloat(p.Utilization, 'f', 4, 64),
+			strconv.FormatFloat(p.QueueTime, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,66 @@
+package ppc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceHistory_Limit(t *testing.T) {
+	h := NewPriceHistory(3)
+	at := time.Unix(1_700_000_000, 0)
+	for i := 0; i < 5; i++ {
+		h.Record(PricePoint{At: at.Add(time.Duration(i) * time.Minute), Price: float64(i)})
+	}
+
+	points := h.Points()
+	require.Len(t, points, 3)
+	assert.Equal(t, 2.0, points[0].Price)
+	assert.Equal(t, 4.0, points[2].Price)
+}
+
+func TestPriceBook_RecordsHistory(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	book, err := NewPriceBook(cfg, 1)
+	require.NoError(t, err)
+	h := NewPriceHistory(0)
+	book.SetHistory(h)
+
+	gpu := ClassOf(types.JobSpecs{GPU: "H100", VRAM: 80})
+	cpu := ClassOf(types.JobSpecs{})
+	at := time.Unix(1_700_000_000, 0)
+
+	for _, offset := range []time.Duration{0, cfg.AdjustmentInterval / 2, cfg.AdjustmentInterval} {
+		_, _, err := book.Adjust(gpu, Observation{Utilization: 1, QueueTime: 60, At: at.Add(offset)})
+		require.NoError(t, err)
+	}
+	_, _, err = book.Adjust(cpu, Observation{Utilization: 0.1, At: at})
+	require.NoError(t, err)
+
+	// The skipped mid-interval observation is not recorded
+	assert.Len(t, h.Points(), 3)
+	require.Len(t, h.Class(gpu), 2)
+	assert.Equal(t, book.Price(gpu), h.Class(gpu)[1].Price)
+	assert.Len(t, h.Class(cpu), 1)
+}
+
+func TestPriceHistory_WriteCSV(t *testing.T) {
+	h := NewPriceHistory(0)
+	h.Record(PricePoint{At: time.Unix(0, 0), Class: ClassOf(types.JobSpecs{}), Price: 1.5, Utilization: 0.7, QueueTime: 60})
+	h.Record(PricePoint{At: time.Unix(300, 0), Price: 2})
+
+	var buf bytes.Buffer
+	require.NoError(t, h.WriteCSV(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "at,class,price,utilization,queue_time", lines[0])
+	assert.Equal(t, "1970-01-01T00:00:00Z,cpu/vram-0/any,1.5,0.7000,60.00", lines[1])
+	assert.Equal(t, "1970-01-01T00:05:00Z,,2,0.0000,0.00", lines[2])
+}
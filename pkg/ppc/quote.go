@@ -0,0 +1,117 @@
+package ppc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+)
+
+// Posted prices are in AXX per hour of the job's resource class. Quotes are
+// converted to base units at 10^Decimals per AXX, after rounding to
+// 10^-QuotePrecision AXX since the float inputs carry no more precision.
+const (
+	Decimals       = 18
+	QuotePrecision = 9
+)
+
+// SLA pricing parameters
+const (
+	// ReferenceLatency is the MaxLatency at or above which no latency
+	// premium applies
+	ReferenceLatency = time.Hour
+	// MaxLatencyMultiplier caps the premium for a tight MaxLatency
+	MaxLatencyMultiplier = 3.0
+	// BaselineUptime is the RequiredUptime at or below which no uptime
+	// premium applies
+	BaselineUptime = 0.95
+	// MaxUptimeMultiplier caps the premium for a high RequiredUptime
+	MaxUptimeMultiplier = 3.0
+)
+
+var (
+	// ErrInvalidDuration is returned when the expected duration is not positive
+	ErrInvalidDuration = errors.New("ppc: expected duration must be positive")
+	// ErrInvalidSLA is returned for a negative MaxLatency or a RequiredUptime outside [0, 1]
+	ErrInvalidSLA = errors.New("ppc: invalid SLA")
+)
+
+// Quote is the expected price of a job and how it was derived
+type Quote struct {
+	Class             ResourceClass `json:"class"`
+	UnitPrice         float64       `json:"unit_price"` // Posted price, AXX per hour
+	Duration          time.Duration `json:"duration"`
+	LatencyMultiplier float64       `json:"latency_multiplier"`
+	UptimeMultiplier  float64       `json:"uptime_multiplier"`
+	Total             *big.Int      `json:"total"` // AXX base units
+}
+
+// Quote prices a job of the given specs, SLA and expected duration at its
+// class's current posted price
+func (b *PriceBook) Quote(spec types.JobSpecs, sla types.SLA, duration time.Duration) (*Quote, error) {
+	class, price := b.PriceFor(spec)
+	return NewQuote(class, price, sla, duration)
+}
+
+// NewQuote prices a job of the given class at a posted price. The total is
+// price × hours × latency multiplier × uptime multiplier.
+func NewQuote(class ResourceClass, price float64, sla types.SLA, duration time.Duration) (*Quote, error) {
+	if !validPrice(price) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrice, price)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDuration, duration)
+	}
+	if sla.MaxLatency < 0 {
+		return nil, fmt.Errorf("%w: max latency %v", ErrInvalidSLA, sla.MaxLatency)
+	}
+	if !(sla.RequiredUptime >= 0 && sla.RequiredUptime <= 1) {
+		return nil, fmt.Errorf("%w: required uptime %v", ErrInvalidSLA, sla.RequiredUptime)
+	}
+
+	q := &Quote{
+		Class:             class,
+		UnitPrice:         price,
+		Duration:          duration,
+		LatencyMultiplier: LatencyMultiplier(sla.MaxLatency),
+		UptimeMultiplier:  UptimeMultiplier(sla.RequiredUptime),
+	}
+
+	axx := price * duration.Hours() * q.LatencyMultiplier * q.UptimeMultiplier
+	scaled := math.Round(axx * math.Pow10(QuotePrecision))
+	if !validPrice(scaled) {
+		return nil, fmt.Errorf("%w: total %v AXX", ErrInvalidPrice, axx)
+	}
+	units, _ := big.NewFloat(scaled).Int(nil)
+	if units == nil {
+		return nil, fmt.Errorf("%w: total %v AXX", ErrInvalidPrice, axx)
+	}
+	q.Total = units.Mul(units, new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals-QuotePrecision), nil))
+	return q, nil
+}
+
+// LatencyMultiplier returns the premium for a MaxLatency. It grows with the
+// square root of how far the limit is below ReferenceLatency, up to
+// MaxLatencyMultiplier. A zero MaxLatency means no limit.
+func LatencyMultiplier(maxLatency time.Duration) float64 {
+	if maxLatency <= 0 || maxLatency >= ReferenceLatency {
+		return 1
+	}
+	return math.Min(math.Sqrt(float64(ReferenceLatency)/float64(maxLatency)), MaxLatencyMultiplier)
+}
+
+// UptimeMultiplier returns the premium for a RequiredUptime. Each tenfold
+// cut in allowed downtime below that of BaselineUptime adds 1, up to
+// MaxUptimeMultiplier.
+func UptimeMultiplier(required float64) float64 {
+	if required <= BaselineUptime {
+		return 1
+	}
+	if required >= 1 {
+		return MaxUptimeMultiplier
+	}
+	return math.Min(1+math.Log10((1-BaselineUptime)/(1-required)), MaxUptimeMultiplier)
+}
@@ -0,0 +1,92 @@
+package ppc
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// axx converts whole AXX to base units
+func axx(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals), nil))
+}
+
+func TestLatencyMultiplier(t *testing.T) {
+	assert.Equal(t, 1.0, LatencyMultiplier(0))
+	assert.Equal(t, 1.0, LatencyMultiplier(2*ReferenceLatency))
+	assert.InDelta(t, 2.0, LatencyMultiplier(ReferenceLatency/4), 1e-12)
+	assert.Equal(t, MaxLatencyMultiplier, LatencyMultiplier(time.Second))
+	assert.Greater(t, LatencyMultiplier(time.Minute), LatencyMultiplier(10*time.Minute))
+}
+
+func TestUptimeMultiplier(t *testing.T) {
+	assert.Equal(t, 1.0, UptimeMultiplier(0))
+	assert.Equal(t, 1.0, UptimeMultiplier(BaselineUptime))
+	assert.InDelta(t, 2.0, UptimeMultiplier(0.995), 1e-9)
+	assert.Equal(t, MaxUptimeMultiplier, UptimeMultiplier(1))
+	assert.Greater(t, UptimeMultiplier(0.999), UptimeMultiplier(0.99))
+}
+
+func TestNewQuote(t *testing.T) {
+	class := ClassOf(types.JobSpecs{GPU: "H100", VRAM: 80})
+
+	q, err := NewQuote(class, 2, types.SLA{}, 3*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, axx(6), q.Total)
+	assert.Equal(t, 1.0, q.LatencyMultiplier)
+	assert.Equal(t, 1.0, q.UptimeMultiplier)
+
+	q, err = NewQuote(class, 2, types.SLA{MaxLatency: ReferenceLatency / 4, RequiredUptime: 0.995}, 3*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, axx(24), q.Total)
+
+	// Sub-AXX totals keep their fractional base units
+	q, err = NewQuote(class, 0.5, types.SLA{}, 90*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Div(axx(3), big.NewInt(4)), q.Total)
+}
+
+func TestNewQuote_Errors(t *testing.T) {
+	class := ClassOf(types.JobSpecs{})
+
+	_, err := NewQuote(class, 1, types.SLA{}, 0)
+	assert.ErrorIs(t, err, ErrInvalidDuration)
+	for _, p := range []float64{0, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = NewQuote(class, p, types.SLA{}, time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidPrice, "price %v", p)
+	}
+	_, err = NewQuote(class, math.MaxFloat64, types.SLA{}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	_, err = NewQuote(class, 1, types.SLA{RequiredUptime: math.NaN()}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidSLA)
+	_, err = NewQuote(class, 1, types.SLA{RequiredUptime: 1.1}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidSLA)
+	_, err = NewQuote(class, 1, types.SLA{MaxLatency: -time.Second}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidSLA)
+}
+
+func TestPriceBook_Quote(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	book, err := NewPriceBook(cfg, 1)
+	require.NoError(t, err)
+
+	busy := ClassOf(types.JobSpecs{GPU: "H100", VRAM: 80})
+	_, _, err = book.Adjust(busy, Observation{Utilization: 1, QueueTime: 5 * cfg.TargetQueueTime, At: time.Unix(1_700_000_000, 0)})
+	require.NoError(t, err)
+
+	hot, err := book.Quote(types.JobSpecs{GPU: "NVIDIA H100", VRAM: 80}, types.SLA{}, time.Hour)
+	require.NoError(t, err)
+	cold, err := book.Quote(types.JobSpecs{GPU: "RTX 4090", VRAM: 24}, types.SLA{}, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, busy, hot.Class)
+	assert.Equal(t, book.Price(busy), hot.UnitPrice)
+	assert.Equal(t, axx(1), cold.Total)
+	assert.Equal(t, 1, hot.Total.Cmp(cold.Total))
+}
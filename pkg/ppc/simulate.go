@@ -0,0 +1,262 @@
+package ppc
+
+import (
+	"container/heap"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/randomness"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Domain tag for synthetic trace generation
+const traceDomain = "axionax/ppc/trace/v1"
+
+// Simulation bounds
+const (
+	// MaxTraceSeconds is the latest arrival and longest duration a trace may
+	// hold, one year
+	MaxTraceSeconds = 365 * 24 * 3600
+	// DefaultMaxIntervals is the default limit on adjustment intervals
+	// a simulation may run
+	DefaultMaxIntervals = 100_000
+)
+
+var (
+	// ErrInvalidTrace is returned for a malformed demand trace
+	ErrInvalidTrace = errors.New("ppc: invalid trace")
+	// ErrInvalidCapacity is returned when the simulated capacity is not positive
+	ErrInvalidCapacity = errors.New("ppc: capacity must be positive")
+	// ErrTooManyIntervals is returned when a simulation does not drain within its interval limit
+	ErrTooManyIntervals = errors.New("ppc: simulation exceeded its interval limit")
+)
+
+// TraceJob is one job arrival in a demand trace. Times are in seconds from
+// the start of the trace.
+type TraceJob struct {
+	Arrival  float64 `json:"arrival"`
+	Duration float64 `json:"duration"`
+	MaxPrice float64 `json:"max_price,omitempty"` // Highest price the client accepts, 0 for any
+}
+
+// ReadTrace parses a CSV demand trace with columns arrival, duration and an
+// optional max_price, all numeric. A header row is skipped. Arrivals and
+// durations beyond MaxTraceSeconds are rejected.
+func ReadTrace(r io.Reader) ([]TraceJob, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTrace, err)
+	}
+
+	var trace []TraceJob
+	for i, rec := range records {
+		if i == 0 && len(rec) > 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "arrival") {
+			continue
+		}
+		if len(rec) < 2 || len(rec) > 3 {
+			return nil, fmt.Errorf("%w: line %d: expected 2 or 3 fields, got %d", ErrInvalidTrace, i+1, len(rec))
+		}
+
+		var fields [3]float64
+		for j, s := range rec {
+			v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, fmt.Errorf("%w: line %d: bad value %q", ErrInvalidTrace, i+1, s)
+			}
+			if j < 2 && v > MaxTraceSeconds {
+				return nil, fmt.Errorf("%w: line %d: %v exceeds %d seconds", ErrInvalidTrace, i+1, v, MaxTraceSeconds)
+			}
+			fields[j] = v
+		}
+		trace = append(trace, TraceJob{Arrival: fields[0], Duration: fields[1], MaxPrice: fields[2]})
+	}
+	return trace, nil
+}
+
+// GenerateTrace returns a reproducible synthetic trace of n jobs with
+// exponentially distributed inter-arrival times and durations. Each job's
+// max price is drawn uniformly from [0, maxPrice], or left unlimited if
+// maxPrice is 0.
+func GenerateTrace(seed common.Hash, n int, meanInterarrival, meanDuration, maxPrice float64) []TraceJob {
+	stream := randomness.NewStream(randomness.DeriveSeed(traceDomain, seed.Bytes()))
+	exp := func(mean float64) float64 {
+		return -mean * math.Log(1-stream.Float64())
+	}
+
+	trace := make([]TraceJob, n)
+	t := 0.0
+	for i := range trace {
+		t += exp(meanInterarrival)
+		trace[i] = TraceJob{Arrival: t, Duration: exp(meanDuration)}
+		if maxPrice > 0 {
+			trace[i].MaxPrice = stream.Float64() * maxPrice
+		}
+	}
+	return trace
+}
+
+// SimulationResult summarizes a trace replayed through a controller
+type SimulationResult struct {
+	Points          []PricePoint `json:"points"` // One per adjustment interval
+	Completed       int          `json:"completed"`
+	Dropped         int          `json:"dropped"` // Arrivals that found the price above their max
+	MeanPrice       float64      `json:"mean_price"`
+	MeanUtilization float64      `json:"mean_utilization"`
+	MeanQueueTime   float64      `json:"mean_queue_time"`
+	FinalPrice      float64      `json:"final_price"`
+}
+
+// simulation is the state of a trace replay
+type simulation struct {
+	capacity int
+	now      float64
+	running  endTimes   // End times of running jobs
+	queue    []TraceJob // Waiting jobs, oldest first
+	busy     float64    // Slot-seconds used in the current interval
+	waits    []float64  // Waits of jobs started in the current interval
+	done     int
+}
+
+// Simulate replays a demand trace through a controller for the given
+// configuration, starting at initial, over capacity identical job slots.
+// Every AdjustmentInterval the utilization and mean queue time of that
+// interval are fed to the controller; arrivals whose max price is below the
+// posted price are dropped. Observations are timestamped from start. The
+// replay fails with ErrTooManyIntervals if the trace has not drained after
+// maxIntervals intervals.
+func Simulate(cfg config.PPCConfig, initial float64, capacity int, trace []TraceJob, start time.Time, maxIntervals int) (*SimulationResult, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidCapacity, capacity)
+	}
+	c, err := NewController(cfg, initial)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := append([]TraceJob(nil), trace...)
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].Arrival < jobs[j].Arrival })
+
+	history := NewPriceHistory(0)
+	interval := cfg.AdjustmentInterval.Seconds()
+	sim := &simulation{capacity: capacity}
+	res := &SimulationResult{}
+
+	next := 0
+	for step := 1; next < len(jobs) || len(sim.queue) > 0 || len(sim.running) > 0; step++ {
+		if step > maxIntervals {
+			return nil, fmt.Errorf("%w: %d intervals of %s", ErrTooManyIntervals, maxIntervals, cfg.AdjustmentInterval)
+		}
+		end := float64(step) * interval
+		price := c.Price()
+		for next < len(jobs) && jobs[next].Arrival < end {
+			j := jobs[next]
+			next++
+			sim.advance(j.Arrival)
+			if j.MaxPrice > 0 && price > j.MaxPrice {
+				res.Dropped++
+				continue
+			}
+			sim.queue = append(sim.queue, j)
+			sim.dispatch()
+		}
+		sim.advance(end)
+
+		obs := Observation{
+			Utilization: sim.busy / (float64(capacity) * interval),
+			QueueTime:   sim.queueTime(),
+			At:          start.Add(time.Duration(step) * cfg.AdjustmentInterval),
+		}
+		price, _, err := c.Adjust(obs)
+		if err != nil {
+			return nil, err
+		}
+		history.Record(PricePoint{At: obs.At, Price: price, Utilization: obs.Utilization, QueueTime: obs.QueueTime})
+		sim.busy, sim.waits = 0, sim.waits[:0]
+	}
+
+	res.Points = history.Points()
+	res.Completed = sim.done
+	res.FinalPrice = c.Price()
+	for _, p := range res.Points {
+		res.MeanPrice += p.Price
+		res.MeanUtilization += p.Utilization
+		res.MeanQueueTime += p.QueueTime
+	}
+	if n := float64(len(res.Points)); n > 0 {
+		res.MeanPrice /= n
+		res.MeanUtilization /= n
+		res.MeanQueueTime /= n
+	}
+	return res, nil
+}
+
+// advance moves the clock to t, completing jobs that end on the way and
+// accumulating busy slot time
+func (s *simulation) advance(t float64) {
+	for len(s.running) > 0 && s.running[0] <= t {
+		end := s.running[0]
+		s.busy += float64(len(s.running)) * (end - s.now)
+		s.now = end
+		heap.Pop(&s.running)
+		s.done++
+		s.dispatch()
+	}
+	s.busy += float64(len(s.running)) * (t - s.now)
+	s.now = t
+}
+
+// dispatch starts queued jobs, oldest first, while slots are free
+func (s *simulation) dispatch() {
+	for len(s.queue) > 0 && len(s.running) < s.capacity {
+		j := s.queue[0]
+		s.queue = s.queue[1:]
+		s.waits = append(s.waits, s.now-j.Arrival)
+		heap.Push(&s.running, s.now+j.Duration)
+	}
+}
+
+// endTimes is a min-heap of running jobs' end times
+type endTimes []float64
+
+func (h endTimes) Len() int            { return len(h) }
+func (h endTimes) Less(i, j int) bool  { return h[i] < h[j] }
+func (h endTimes) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *endTimes) Push(x interface{}) { *h = append(*h, x.(float64)) }
+
+func (h *endTimes) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// queueTime returns the mean wait of jobs started this interval and jobs
+// still waiting, counting the latter up to now
+func (s *simulation) queueTime() float64 {
+	total, n := 0.0, 0
+	for _, w := range s.waits {
+		total += w
+		n++
+	}
+	for _, j := range s.queue {
+		total += s.now - j.Arrival
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
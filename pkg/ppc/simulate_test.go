@@ -0,0 +1,111 @@
+package ppc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTrace(t *testing.T) {
+	trace, err := ReadTrace(strings.NewReader(`arrival,duration,max_price
+# warm-up
+0,600
+30, 120, 2.5
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []TraceJob{
+		{Arrival: 0, Duration: 600},
+		{Arrival: 30, Duration: 120, MaxPrice: 2.5},
+	}, trace)
+
+	for _, bad := range []string{"1\n", "1,2,3,4\n", "1,abc\n", "-1,5\n", "0,1e13\n", "1e13,5\n"} {
+		_, err := ReadTrace(strings.NewReader(bad))
+		assert.ErrorIs(t, err, ErrInvalidTrace, bad)
+	}
+}
+
+func TestGenerateTrace_Reproducible(t *testing.T) {
+	seed := common.HexToHash("0x42")
+	a := GenerateTrace(seed, 100, 10, 60, 5)
+	b := GenerateTrace(seed, 100, 10, 60, 5)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, GenerateTrace(common.HexToHash("0x43"), 100, 10, 60, 5))
+
+	for i := 1; i < len(a); i++ {
+		assert.Greater(t, a[i].Arrival, a[i-1].Arrival)
+		assert.LessOrEqual(t, a[i].MaxPrice, 5.0)
+	}
+}
+
+func TestSimulate_Accounting(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	cfg.AdjustmentInterval = 100 * time.Second
+	start := time.Unix(1_700_000_000, 0)
+
+	// Two slots, three 150s jobs at t=0: the third waits 150s
+	trace := []TraceJob{{0, 150, 0}, {0, 150, 0}, {0, 150, 0}}
+	res, err := Simulate(cfg, 1, 2, trace, start, DefaultMaxIntervals)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, res.Completed)
+	assert.Equal(t, 0, res.Dropped)
+	require.Len(t, res.Points, 3)
+
+	assert.InDelta(t, 1.0, res.Points[0].Utilization, 1e-9)
+	assert.InDelta(t, 100.0/3, res.Points[0].QueueTime, 1e-9) // waits 0, 0 and 100 so far
+	assert.InDelta(t, 0.75, res.Points[1].Utilization, 1e-9)  // one slot idles after t=150
+	assert.InDelta(t, 150.0, res.Points[1].QueueTime, 1e-9)
+	assert.InDelta(t, 0.5, res.Points[2].Utilization, 1e-9)
+	assert.Equal(t, start.Add(300*time.Second), res.Points[2].At)
+	assert.Equal(t, res.Points[2].Price, res.FinalPrice)
+}
+
+func TestSimulate_PriceRespondsToDemand(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	cfg.Alpha, cfg.Beta = 0.5, 0.3
+	seed := common.HexToHash("0x1")
+
+	// Arrivals far above what 4 slots can serve at 60s each
+	heavy, err := Simulate(cfg, 1, 4, GenerateTrace(seed, 2000, 5, 60, 0), time.Time{}, DefaultMaxIntervals)
+	require.NoError(t, err)
+	light, err := Simulate(cfg, 1, 4, GenerateTrace(seed, 200, 300, 60, 0), time.Time{}, DefaultMaxIntervals)
+	require.NoError(t, err)
+
+	assert.Greater(t, heavy.MeanPrice, light.MeanPrice)
+	assert.Greater(t, heavy.MeanQueueTime, light.MeanQueueTime)
+	assert.Less(t, light.FinalPrice, 1.0)
+	for _, p := range heavy.Points {
+		assert.LessOrEqual(t, p.Price, cfg.MaxPrice)
+		assert.GreaterOrEqual(t, p.Price, cfg.MinPrice)
+	}
+}
+
+func TestSimulate_DropsPricedOutJobs(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	trace := []TraceJob{{Arrival: 0, Duration: 10, MaxPrice: 0.5}, {Arrival: 1, Duration: 10, MaxPrice: 5}}
+
+	res, err := Simulate(cfg, 1, 1, trace, time.Time{}, DefaultMaxIntervals)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Dropped)
+	assert.Equal(t, 1, res.Completed)
+
+	_, err = Simulate(cfg, 1, 0, trace, time.Time{}, DefaultMaxIntervals)
+	assert.ErrorIs(t, err, ErrInvalidCapacity)
+}
+
+func TestSimulate_IntervalLimit(t *testing.T) {
+	cfg := config.DefaultConfig().PPC
+	trace := []TraceJob{{Arrival: 0, Duration: 10 * cfg.AdjustmentInterval.Seconds()}}
+
+	res, err := Simulate(cfg, 1, 1, trace, time.Time{}, 10)
+	require.NoError(t, err)
+	assert.Len(t, res.Points, 10)
+
+	_, err = Simulate(cfg, 1, 1, trace, time.Time{}, 9)
+	assert.ErrorIs(t, err, ErrTooManyIntervals)
+}
@@ -0,0 +1,69 @@
+package randomness
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream_Deterministic(t *testing.T) {
+	seed := DeriveSeed("test", []byte("seed"))
+
+	a := NewStream(seed)
+	b := NewStream(seed)
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Uint64(), b.Uint64())
+	}
+}
+
+func TestStream_DifferentSeeds(t *testing.T) {
+	a := NewStream(DeriveSeed("test", []byte("one")))
+	b := NewStream(DeriveSeed("test", []byte("two")))
+
+	assert.NotEqual(t, a.Uint64(), b.Uint64())
+}
+
+func TestDeriveSeed_DomainSeparation(t *testing.T) {
+	part := []byte("payload")
+
+	assert.NotEqual(t, DeriveSeed("a", part), DeriveSeed("b", part))
+	assert.NotEqual(t, common.Hash{}, DeriveSeed("a", part))
+}
+
+func TestStream_IntnRange(t *testing.T) {
+	s := NewStream(DeriveSeed("test"))
+	counts := make([]int, 10)
+
+	for i := 0; i < 10000; i++ {
+		v := s.Intn(10)
+		assert.GreaterOrEqual(t, v, 0)
+		assert.Less(t, v, 10)
+		counts[v]++
+	}
+
+	// Each bucket should be roughly 1000
+	for _, c := range counts {
+		assert.InDelta(t, 1000, c, 200)
+	}
+}
+
+func TestStream_Float64Range(t *testing.T) {
+	s := NewStream(DeriveSeed("test"))
+
+	for i := 0; i < 1000; i++ {
+		f := s.Float64()
+		assert.GreaterOrEqual(t, f, 0.0)
+		assert.Less(t, f, 1.0)
+	}
+}
+
+func TestStream_Shuffle(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	NewStream(DeriveSeed("test")).Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, items)
+}
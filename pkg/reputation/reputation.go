@@ -0,0 +1,260 @@
+// Package reputation maintains worker reputation from job outcomes, PoPC
+// results, DA audits and slashing events
+package reputation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/asr"
+	"github.com/axionaxprotocol/axionax-core/pkg/slashing"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrInvalidWeight is returned when a weight is outside [0, 1]
+	ErrInvalidWeight = errors.New("reputation: weight must be between 0 and 1")
+	// ErrUnknownEvent is returned for an event kind the engine does not handle
+	ErrUnknownEvent = errors.New("reputation: unknown event kind")
+	// ErrWorkerMismatch is returned when an event names a different worker
+	ErrWorkerMismatch = errors.New("reputation: event is for a different worker")
+)
+
+// EventKind identifies what produced a reputation event
+type EventKind string
+
+const (
+	EventJob     EventKind = "job"      // Job completed or failed
+	EventPoPC    EventKind = "popc"     // PoPC verification passed or failed
+	EventDAAudit EventKind = "da_audit" // DA audit served or missed
+	EventSlash   EventKind = "slash"    // Worker was slashed, always negative
+)
+
+// Event is one observation about a worker
+type Event struct {
+	Kind     EventKind      `json:"kind"`
+	Worker   common.Address `json:"worker"`
+	JobID    string         `json:"job_id,omitempty"`
+	Positive bool           `json:"positive"`
+	Detail   string         `json:"detail,omitempty"`
+	At       time.Time      `json:"at"`
+}
+
+// Weights set how far one event moves reputation. A positive event closes
+// that fraction of the gap to 1; a negative event removes that fraction of
+// the current reputation.
+type Weights struct {
+	Job     float64 `json:"job"`
+	PoPC    float64 `json:"popc"`
+	DAAudit float64 `json:"da_audit"`
+	Slash   float64 `json:"slash"`
+}
+
+// DefaultWeights returns the default event weights
+func DefaultWeights() Weights {
+	return Weights{
+		Job:     0.02,
+		PoPC:    0.05,
+		DAAudit: 0.03,
+		Slash:   0.50,
+	}
+}
+
+// Validate checks that every weight is in [0, 1]
+func (w Weights) Validate() error {
+	for _, kind := range []EventKind{EventJob, EventPoPC, EventDAAudit, EventSlash} {
+		if v, _ := w.of(kind); v < 0 || v > 1 {
+			return fmt.Errorf("%w: %s %v", ErrInvalidWeight, kind, v)
+		}
+	}
+	return nil
+}
+
+// of returns the weight for an event kind
+func (w Weights) of(kind EventKind) (float64, bool) {
+	switch kind {
+	case EventJob:
+		return w.Job, true
+	case EventPoPC:
+		return w.PoPC, true
+	case EventDAAudit:
+		return w.DAAudit, true
+	case EventSlash:
+		return w.Slash, true
+	}
+	return 0, false
+}
+
+// Change is the effect of one event on a worker's reputation
+type Change struct {
+	Event  Event   `json:"event"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// Delta returns the change in reputation
+func (c Change) Delta() float64 {
+	return c.After - c.Before
+}
+
+// Explanation breaks a worker's reputation down by the events that moved it
+type Explanation struct {
+	Worker        common.Address        `json:"worker"`
+	Initial       float64               `json:"initial"`
+	Current       float64               `json:"current"`
+	Contributions map[EventKind]float64 `json:"contributions"` // Net change per event kind
+	Changes       []Change              `json:"changes"`       // Oldest first
+}
+
+// String renders the explanation as one line per event kind
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "reputation %.4f (started at %.4f)", e.Current, e.Initial)
+
+	kinds := make([]string, 0, len(e.Contributions))
+	for k := range e.Contributions {
+		kinds = append(kinds, string(k))
+	}
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		fmt.Fprintf(&b, "\n  %-9s %+.4f", k, e.Contributions[EventKind(k)])
+	}
+	return b.String()
+}
+
+// Engine updates worker reputation from events and keeps the history needed
+// to explain it
+type Engine struct {
+	mu      sync.Mutex
+	weights Weights
+	initial map[common.Address]float64
+	changes map[common.Address][]Change
+}
+
+// NewEngine creates a reputation engine with the given weights
+func NewEngine(w Weights) (*Engine, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return &Engine{
+		weights: w,
+		initial: make(map[common.Address]float64),
+		changes: make(map[common.Address][]Change),
+	}, nil
+}
+
+// Apply updates a worker's reputation for one event and returns the change.
+// The result is always clamped to [0, 1].
+func (e *Engine) Apply(w *types.Worker, ev Event) (Change, error) {
+	if ev.Worker != w.Address {
+		return Change{}, fmt.Errorf("%w: event for %s, worker %s", ErrWorkerMismatch, ev.Worker.Hex(), w.Address.Hex())
+	}
+	weight, ok := e.weights.of(ev.Kind)
+	if !ok {
+		return Change{}, fmt.Errorf("%w: %q", ErrUnknownEvent, ev.Kind)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	before := clamp01(w.Reputation)
+	if _, seen := e.initial[w.Address]; !seen {
+		e.initial[w.Address] = before
+	}
+
+	after := before
+	if ev.Positive && ev.Kind != EventSlash {
+		after += weight * (1 - before)
+	} else {
+		after -= weight * before
+	}
+	after = clamp01(after)
+
+	w.Reputation = after
+	c := Change{Event: ev, Before: before, After: after}
+	e.changes[w.Address] = append(e.changes[w.Address], c)
+	return c, nil
+}
+
+// ApplyOutcome applies the job and PoPC events of a recorded outcome. DA
+// events come only from audits, through ApplyAudit.
+func (e *Engine) ApplyOutcome(w *types.Worker, o asr.JobOutcome) ([]Change, error) {
+	events := []Event{
+		{Kind: EventJob, Positive: o.Success},
+		{Kind: EventPoPC, Positive: o.PoPCPassed},
+	}
+
+	changes := make([]Change, 0, len(events))
+	for _, ev := range events {
+		ev.Worker, ev.JobID, ev.At = o.Worker, o.JobID, o.At
+		c, err := e.Apply(w, ev)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// ApplyAudit applies the DA event of an audit request made to the worker
+func (e *Engine) ApplyAudit(w *types.Worker, a asr.AuditOutcome) (Change, error) {
+	return e.Apply(w, Event{
+		Kind:     EventDAAudit,
+		Worker:   a.Worker,
+		JobID:    a.JobID,
+		Positive: a.Passed,
+		Detail:   fmt.Sprintf("shard %d", a.Shard),
+		At:       a.At,
+	})
+}
+
+// ApplySlash applies a slashing record to the slashed worker
+func (e *Engine) ApplySlash(w *types.Worker, rec *slashing.Record) (Change, error) {
+	return e.Apply(w, Event{
+		Kind:   EventSlash,
+		Worker: rec.Offender,
+		JobID:  rec.JobID,
+		Detail: string(rec.Offence),
+		At:     rec.SlashedAt,
+	})
+}
+
+// Explain returns the breakdown of a worker's reputation, or nil if no event
+// has been applied to it
+func (e *Engine) Explain(addr common.Address) *Explanation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	initial, ok := e.initial[addr]
+	if !ok {
+		return nil
+	}
+
+	changes := append([]Change(nil), e.changes[addr]...)
+	ex := &Explanation{
+		Worker:        addr,
+		Initial:       initial,
+		Current:       changes[len(changes)-1].After,
+		Contributions: make(map[EventKind]float64),
+		Changes:       changes,
+	}
+	for _, c := range changes {
+		ex.Contributions[c.Event.Kind] += c.Delta()
+	}
+	return ex
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
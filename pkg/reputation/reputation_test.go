@@ -0,0 +1,164 @@
+package reputation
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/asr"
+	"github.com/axionaxprotocol/axionax-core/pkg/slashing"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorker(reputation float64) *types.Worker {
+	return &types.Worker{
+		Address:    common.HexToAddress("0x1"),
+		Status:     types.WorkerStatusActive,
+		Reputation: reputation,
+	}
+}
+
+func newEngine(t *testing.T) *Engine {
+	e, err := NewEngine(DefaultWeights())
+	require.NoError(t, err)
+	return e
+}
+
+func TestNewEngine_RejectsInvalidWeights(t *testing.T) {
+	w := DefaultWeights()
+	w.Slash = 1.5
+	_, err := NewEngine(w)
+	assert.ErrorIs(t, err, ErrInvalidWeight)
+
+	w = DefaultWeights()
+	w.PoPC = -0.1
+	_, err = NewEngine(w)
+	assert.ErrorIs(t, err, ErrInvalidWeight)
+}
+
+func TestEngine_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    float64
+		kind     EventKind
+		positive bool
+		want     float64
+	}{
+		{"popc pass closes gap", 0.5, EventPoPC, true, 0.525},
+		{"popc fail scales down", 0.5, EventPoPC, false, 0.475},
+		{"job success", 0.8, EventJob, true, 0.804},
+		{"da miss", 0.8, EventDAAudit, false, 0.776},
+		{"slash halves", 0.8, EventSlash, false, 0.4},
+		{"slash ignores positive", 0.8, EventSlash, true, 0.4},
+		{"out of range is clamped", 1.5, EventJob, true, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEngine(t)
+			w := testWorker(tt.start)
+
+			c, err := e.Apply(w, Event{Kind: tt.kind, Worker: w.Address, Positive: tt.positive})
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, w.Reputation, 1e-9)
+			assert.InDelta(t, tt.want, c.After, 1e-9)
+		})
+	}
+}
+
+func TestEngine_ApplyErrors(t *testing.T) {
+	e := newEngine(t)
+	w := testWorker(0.5)
+
+	_, err := e.Apply(w, Event{Kind: "bogus", Worker: w.Address})
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+
+	_, err = e.Apply(w, Event{Kind: EventJob, Worker: common.HexToAddress("0x2")})
+	assert.ErrorIs(t, err, ErrWorkerMismatch)
+
+	assert.Equal(t, 0.5, w.Reputation)
+	assert.Nil(t, e.Explain(w.Address))
+}
+
+func TestEngine_StaysInRange(t *testing.T) {
+	e := newEngine(t)
+	w := testWorker(0.5)
+
+	for i := 0; i < 1000; i++ {
+		_, err := e.Apply(w, Event{Kind: EventPoPC, Worker: w.Address, Positive: true})
+		require.NoError(t, err)
+	}
+	assert.LessOrEqual(t, w.Reputation, 1.0)
+
+	for i := 0; i < 100; i++ {
+		_, err := e.Apply(w, Event{Kind: EventSlash, Worker: w.Address})
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, w.Reputation, 0.0)
+}
+
+func TestEngine_ApplyOutcomeAndSlash(t *testing.T) {
+	e := newEngine(t)
+	w := testWorker(0.6)
+	at := time.Unix(1_700_000_000, 0)
+
+	changes, err := e.ApplyOutcome(w, asr.JobOutcome{
+		JobID: "job-1", Worker: w.Address, Success: true, PoPCPassed: false, At: at,
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 2, "an outcome alone carries no DA event")
+	assert.Greater(t, changes[0].Delta(), 0.0)
+	assert.Less(t, changes[1].Delta(), 0.0)
+	assert.Equal(t, "job-1", changes[1].Event.JobID)
+
+	c, err := e.ApplyAudit(w, asr.AuditOutcome{JobID: "job-1", Worker: w.Address, Shard: 3, Passed: true, At: at})
+	require.NoError(t, err)
+	assert.Equal(t, EventDAAudit, c.Event.Kind)
+	assert.Equal(t, "shard 3", c.Event.Detail)
+	assert.Greater(t, c.Delta(), 0.0)
+
+	rec := &slashing.Record{
+		Evidence:  slashing.Evidence{Offence: slashing.OffenceWorkerFraud, Offender: w.Address, JobID: "job-1"},
+		Penalty:   big.NewInt(1),
+		SlashedAt: at,
+	}
+	c, err = e.ApplySlash(w, rec)
+	require.NoError(t, err)
+	assert.Equal(t, "worker_fraud", c.Event.Detail)
+	assert.InDelta(t, c.Before/2, c.After, 1e-9)
+}
+
+func TestEngine_Explain(t *testing.T) {
+	e := newEngine(t)
+	w := testWorker(0.6)
+
+	for _, ev := range []Event{
+		{Kind: EventJob, Positive: true},
+		{Kind: EventPoPC, Positive: true},
+		{Kind: EventPoPC, Positive: false},
+		{Kind: EventSlash},
+	} {
+		ev.Worker = w.Address
+		_, err := e.Apply(w, ev)
+		require.NoError(t, err)
+	}
+
+	ex := e.Explain(w.Address)
+	require.NotNil(t, ex)
+	assert.Equal(t, 0.6, ex.Initial)
+	assert.Equal(t, w.Reputation, ex.Current)
+	assert.Len(t, ex.Changes, 4)
+
+	// Contributions add up to the net change
+	sum := 0.0
+	for _, v := range ex.Contributions {
+		sum += v
+	}
+	assert.InDelta(t, ex.Current-ex.Initial, sum, 1e-9)
+	assert.Less(t, ex.Contributions[EventSlash], 0.0)
+	assert.Greater(t, ex.Contributions[EventJob], 0.0)
+	assert.Contains(t, ex.String(), "slash")
+}
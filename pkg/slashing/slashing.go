@@ -0,0 +1,189 @@
+// Package slashing applies stake penalties for proven protocol offences
+package slashing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BasisPoints is the denominator for penalty rates
+const BasisPoints = 10000
+
+var (
+	// ErrInvalidRate is returned when a configured penalty is outside [0, 100%]
+	ErrInvalidRate = errors.New("slashing: penalty rate must be between 0 and 10000 basis points")
+	// ErrWrongOffender is returned when an offence is applied to the wrong kind of participant
+	ErrWrongOffender = errors.New("slashing: offence does not apply to this participant")
+	// ErrOffenderMismatch is returned when evidence names a different offender
+	ErrOffenderMismatch = errors.New("slashing: evidence is for a different offender")
+	// ErrAlreadySlashed is returned when the same evidence is applied twice
+	ErrAlreadySlashed = errors.New("slashing: offence already slashed")
+)
+
+// Offence identifies a slashable protocol violation
+type Offence string
+
+const (
+	OffenceWorkerFraud      Offence = "worker_fraud"      // Worker committed an incorrect result
+	OffenceFalsePass        Offence = "false_pass"        // Validator passed a fraudulent result
+	OffenceDAUnavailability Offence = "da_unavailability" // Worker failed to serve committed data
+)
+
+// Evidence is a proven offence ready to be slashed
+type Evidence struct {
+	Offence  Offence        `json:"offence"`
+	Offender common.Address `json:"offender"`
+	JobID    string         `json:"job_id"`
+	Detail   string         `json:"detail,omitempty"`
+}
+
+// Record is the result of applying a slash
+type Record struct {
+	Evidence
+	PenaltyBps  int64     `json:"penalty_bps"`
+	StakeBefore *big.Int  `json:"stake_before"`
+	Penalty     *big.Int  `json:"penalty"`
+	StakeAfter  *big.Int  `json:"stake_after"`
+	Status      string    `json:"status"` // Offender status after slashing
+	SlashedAt   time.Time `json:"slashed_at"`
+}
+
+// Engine computes and applies penalties using ConsensusConfig.SlashingRate
+// for worker offences and FalsePassPenalty for validator false passes
+type Engine struct {
+	mu           sync.Mutex
+	workerBps    int64
+	falsePassBps int64
+	applied      map[string]bool
+	records      []Record
+}
+
+// NewEngine creates a slashing engine from the consensus configuration
+func NewEngine(cfg config.ConsensusConfig) (*Engine, error) {
+	workerBps := int64(math.Round(cfg.SlashingRate * BasisPoints))
+	falsePassBps := int64(cfg.FalsePassPenalty)
+
+	if workerBps < 0 || workerBps > BasisPoints {
+		return nil, fmt.Errorf("%w: slashing_rate %v", ErrInvalidRate, cfg.SlashingRate)
+	}
+	if falsePassBps < 0 || falsePassBps > BasisPoints {
+		return nil, fmt.Errorf("%w: false_pass_penalty %d", ErrInvalidRate, cfg.FalsePassPenalty)
+	}
+
+	return &Engine{
+		workerBps:    workerBps,
+		falsePassBps: falsePassBps,
+		applied:      make(map[string]bool),
+	}, nil
+}
+
+// Penalty returns stake * bps / 10000, rounded down
+func Penalty(stake *big.Int, bps int64) *big.Int {
+	if stake == nil || stake.Sign() <= 0 || bps <= 0 {
+		return new(big.Int)
+	}
+	p := new(big.Int).Mul(stake, big.NewInt(bps))
+	return p.Quo(p, big.NewInt(BasisPoints))
+}
+
+// SlashWorker applies a worker offence: the stake is reduced by SlashingRate
+// and the worker is marked slashed
+func (e *Engine) SlashWorker(w *types.Worker, ev Evidence, now time.Time) (*Record, error) {
+	if ev.Offence != OffenceWorkerFraud && ev.Offence != OffenceDAUnavailability {
+		return nil, fmt.Errorf("%w: %s on worker", ErrWrongOffender, ev.Offence)
+	}
+	if ev.Offender != w.Address {
+		return nil, ErrOffenderMismatch
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.applied[evidenceKey(ev)] {
+		return nil, fmt.Errorf("%w: %s %s for job %s", ErrAlreadySlashed, ev.Offence, ev.Offender.Hex(), ev.JobID)
+	}
+
+	before, penalty, after := apply(w.Stake, e.workerBps)
+	w.Stake = after
+	w.Status = types.WorkerStatusSlashed
+
+	return e.record(ev, e.workerBps, before, penalty, after, string(w.Status), now), nil
+}
+
+// SlashValidator applies a validator false pass: the stake is reduced by
+// FalsePassPenalty and the validator is jailed
+func (e *Engine) SlashValidator(v *types.Validator, ev Evidence, now time.Time) (*Record, error) {
+	if ev.Offence != OffenceFalsePass {
+		return nil, fmt.Errorf("%w: %s on validator", ErrWrongOffender, ev.Offence)
+	}
+	if ev.Offender != v.Address {
+		return nil, ErrOffenderMismatch
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.applied[evidenceKey(ev)] {
+		return nil, fmt.Errorf("%w: %s %s for job %s", ErrAlreadySlashed, ev.Offence, ev.Offender.Hex(), ev.JobID)
+	}
+
+	before, penalty, after := apply(v.Stake, e.falsePassBps)
+	v.Stake = after
+	if v.Status != types.ValidatorStatusSlashed {
+		v.Status = types.ValidatorStatusJailed
+	}
+
+	return e.record(ev, e.falsePassBps, before, penalty, after, string(v.Status), now), nil
+}
+
+// Records returns every slash applied so far, oldest first
+func (e *Engine) Records() []Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]Record, len(e.records))
+	copy(out, e.records)
+	return out
+}
+
+// apply computes the penalty on a stake and returns copies of the stake
+// before and after
+func apply(stake *big.Int, bps int64) (before, penalty, after *big.Int) {
+	before = new(big.Int)
+	if stake != nil {
+		before.Set(stake)
+	}
+	penalty = Penalty(before, bps)
+	after = new(big.Int).Sub(before, penalty)
+	return before, penalty, after
+}
+
+// record stores a slashing record; the caller must hold e.mu
+func (e *Engine) record(ev Evidence, bps int64, before, penalty, after *big.Int, status string, now time.Time) *Record {
+	e.applied[evidenceKey(ev)] = true
+
+	rec := Record{
+		Evidence:    ev,
+		PenaltyBps:  bps,
+		StakeBefore: before,
+		Penalty:     penalty,
+		StakeAfter:  after,
+		Status:      status,
+		SlashedAt:   now,
+	}
+	e.records = append(e.records, rec)
+	return &rec
+}
+
+// evidenceKey identifies an offence so it is only slashed once
+func evidenceKey(ev Evidence) string {
+	return string(ev.Offence) + "/" + ev.Offender.Hex() + "/" + ev.JobID
+}
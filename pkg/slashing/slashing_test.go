@@ -0,0 +1,133 @@
+package slashing
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/axionaxprotocol/axionax-core/pkg/config"
+	"github.com/axionaxprotocol/axionax-core/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func axx(n int64) *big.Int {
+	// n AXX in base units (10^18)
+	return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+}
+
+func newEngine(t *testing.T) *Engine {
+	e, err := NewEngine(config.DefaultConfig().Consensus)
+	require.NoError(t, err)
+	return e
+}
+
+func TestPenalty(t *testing.T) {
+	tests := []struct {
+		name     string
+		stake    *big.Int
+		bps      int64
+		expected *big.Int
+	}{
+		{"10% of 10000 AXX", axx(10000), 1000, axx(1000)},
+		{"5% of 50000 AXX", axx(50000), 500, axx(2500)},
+		{"Rounds down", big.NewInt(19999), 500, big.NewInt(999)},
+		{"Nil stake", nil, 500, big.NewInt(0)},
+		{"Zero rate", axx(1), 0, big.NewInt(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, 0, tt.expected.Cmp(Penalty(tt.stake, tt.bps)))
+		})
+	}
+}
+
+func TestNewEngine_InvalidRates(t *testing.T) {
+	cfg := config.DefaultConfig().Consensus
+	cfg.SlashingRate = 1.5
+	_, err := NewEngine(cfg)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+
+	cfg = config.DefaultConfig().Consensus
+	cfg.FalsePassPenalty = -1
+	_, err = NewEngine(cfg)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func TestSlashWorker(t *testing.T) {
+	e := newEngine(t)
+	w := &types.Worker{
+		Address: common.HexToAddress("0x01"),
+		Stake:   axx(10000),
+		Status:  types.WorkerStatusActive,
+	}
+	now := time.Now()
+
+	rec, err := e.SlashWorker(w, Evidence{
+		Offence:  OffenceWorkerFraud,
+		Offender: w.Address,
+		JobID:    "job-1",
+	}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1000), rec.PenaltyBps)
+	assert.Equal(t, 0, axx(1000).Cmp(rec.Penalty))
+	assert.Equal(t, 0, axx(10000).Cmp(rec.StakeBefore))
+	assert.Equal(t, 0, axx(9000).Cmp(rec.StakeAfter))
+	assert.Equal(t, 0, axx(9000).Cmp(w.Stake))
+	assert.Equal(t, types.WorkerStatusSlashed, w.Status)
+	assert.Equal(t, string(types.WorkerStatusSlashed), rec.Status)
+	assert.Equal(t, now, rec.SlashedAt)
+
+	// The same offence cannot be slashed twice
+	_, err = e.SlashWorker(w, Evidence{Offence: OffenceWorkerFraud, Offender: w.Address, JobID: "job-1"}, now)
+	assert.ErrorIs(t, err, ErrAlreadySlashed)
+
+	// A different offence on the same job can
+	rec, err = e.SlashWorker(w, Evidence{Offence: OffenceDAUnavailability, Offender: w.Address, JobID: "job-1"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, axx(8100).Cmp(w.Stake))
+
+	assert.Len(t, e.Records(), 2)
+}
+
+func TestSlashValidator(t *testing.T) {
+	e := newEngine(t)
+	v := &types.Validator{
+		Address: common.HexToAddress("0x02"),
+		Stake:   axx(50000),
+		Status:  types.ValidatorStatusActive,
+	}
+
+	rec, err := e.SlashValidator(v, Evidence{
+		Offence:  OffenceFalsePass,
+		Offender: v.Address,
+		JobID:    "job-2",
+	}, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(500), rec.PenaltyBps)
+	assert.Equal(t, 0, axx(2500).Cmp(rec.Penalty))
+	assert.Equal(t, 0, axx(47500).Cmp(v.Stake))
+	assert.Equal(t, types.ValidatorStatusJailed, v.Status)
+}
+
+func TestSlash_WrongOffender(t *testing.T) {
+	e := newEngine(t)
+	w := &types.Worker{Address: common.HexToAddress("0x01"), Stake: axx(1)}
+	v := &types.Validator{Address: common.HexToAddress("0x02"), Stake: axx(1)}
+
+	_, err := e.SlashWorker(w, Evidence{Offence: OffenceFalsePass, Offender: w.Address}, time.Now())
+	assert.ErrorIs(t, err, ErrWrongOffender)
+
+	_, err = e.SlashValidator(v, Evidence{Offence: OffenceWorkerFraud, Offender: v.Address}, time.Now())
+	assert.ErrorIs(t, err, ErrWrongOffender)
+
+	_, err = e.SlashWorker(w, Evidence{Offence: OffenceWorkerFraud, Offender: v.Address}, time.Now())
+	assert.ErrorIs(t, err, ErrOffenderMismatch)
+
+	assert.Empty(t, e.Records())
+	assert.Equal(t, 0, axx(1).Cmp(w.Stake))
+}
@@ -0,0 +1,13 @@
+package types
+
+import "strings"
+
+// NormalizeGPU makes "NVIDIA RTX 4090", "nvidia rtx  4090" and "RTX 4090"
+// compare equal by lowercasing the model and dropping a leading vendor name
+func NormalizeGPU(model string) string {
+	fields := strings.Fields(strings.ToLower(model))
+	if len(fields) > 1 && (fields[0] == "nvidia" || fields[0] == "amd") {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
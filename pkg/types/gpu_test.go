@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeGPU(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"NVIDIA RTX 4090", "rtx 4090"},
+		{"nvidia rtx  4090", "rtx 4090"},
+		{"RTX 4090", "rtx 4090"},
+		{"AMD MI300X", "mi300x"},
+		{"NVIDIA", "nvidia"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeGPU(tt.model), tt.model)
+	}
+}
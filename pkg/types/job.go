@@ -0,0 +1,124 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrInvalidTransition is wrapped by every TransitionError
+	ErrInvalidTransition = errors.New("invalid job status transition")
+	// ErrNoWorker is returned when assigning a job to the zero address
+	ErrNoWorker = errors.New("job must be assigned to a worker")
+	// ErrNoOutputRoot is returned when committing a job without an output root
+	ErrNoOutputRoot = errors.New("job commit requires an output root")
+)
+
+// TransitionError reports an illegal job status change
+type TransitionError struct {
+	JobID string
+	From  JobStatus
+	To    JobStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("job %s: cannot move from %s to %s", e.JobID, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// jobTransitions lists the legal next states of every job status. Terminal
+// statuses have none.
+var jobTransitions = map[JobStatus][]JobStatus{
+	JobStatusPending:    {JobStatusAssigned, JobStatusFailed},
+	JobStatusAssigned:   {JobStatusExecuting, JobStatusFailed},
+	JobStatusExecuting:  {JobStatusCommitted, JobStatusFailed},
+	JobStatusCommitted:  {JobStatusValidating, JobStatusFailed, JobStatusSlashed},
+	JobStatusValidating: {JobStatusCompleted, JobStatusFailed, JobStatusSlashed},
+	JobStatusCompleted:  nil,
+	JobStatusFailed:     nil,
+	JobStatusSlashed:    nil,
+}
+
+// CanTransition reports whether a job may move from one status to another
+func CanTransition(from, to JobStatus) bool {
+	for _, next := range jobTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether s is a known status with no further
+// transitions. Unknown and empty statuses are not terminal.
+func (s JobStatus) IsTerminal() bool {
+	next, ok := jobTransitions[s]
+	return ok && len(next) == 0
+}
+
+// Assign hands a pending job to a worker
+func (j *Job) Assign(worker common.Address) error {
+	if worker == (common.Address{}) {
+		return ErrNoWorker
+	}
+	if err := j.transition(JobStatusAssigned); err != nil {
+		return err
+	}
+	j.Worker = worker
+	return nil
+}
+
+// StartExecution marks an assigned job as executing
+func (j *Job) StartExecution() error {
+	return j.transition(JobStatusExecuting)
+}
+
+// Commit records the worker's output root for an executing job
+func (j *Job) Commit(outputRoot common.Hash) error {
+	if outputRoot == (common.Hash{}) {
+		return ErrNoOutputRoot
+	}
+	if err := j.transition(JobStatusCommitted); err != nil {
+		return err
+	}
+	j.OutputRoot = outputRoot
+	return nil
+}
+
+// BeginValidation moves a committed job into PoPC validation
+func (j *Job) BeginValidation() error {
+	return j.transition(JobStatusValidating)
+}
+
+// Complete finalizes a validated job and stamps CompletedAt
+func (j *Job) Complete(at time.Time) error {
+	if err := j.transition(JobStatusCompleted); err != nil {
+		return err
+	}
+	j.CompletedAt = &at
+	return nil
+}
+
+// Fail marks any unfinished job as failed
+func (j *Job) Fail() error {
+	return j.transition(JobStatusFailed)
+}
+
+// Slash marks a committed or validating job as slashed for fraud
+func (j *Job) Slash() error {
+	return j.transition(JobStatusSlashed)
+}
+
+func (j *Job) transition(to JobStatus) error {
+	if !CanTransition(j.Status, to) {
+		return &TransitionError{JobID: j.ID, From: j.Status, To: to}
+	}
+	j.Status = to
+	return nil
+}
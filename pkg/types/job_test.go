@@ -0,0 +1,112 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_Lifecycle(t *testing.T) {
+	job := Job{ID: "lifecycle", Status: JobStatusPending, SubmittedAt: time.Now()}
+	worker := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	root := common.HexToHash("0xabcdef")
+	completedAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, job.Assign(worker))
+	assert.Equal(t, JobStatusAssigned, job.Status)
+	assert.Equal(t, worker, job.Worker)
+
+	require.NoError(t, job.StartExecution())
+	assert.Equal(t, JobStatusExecuting, job.Status)
+
+	require.NoError(t, job.Commit(root))
+	assert.Equal(t, JobStatusCommitted, job.Status)
+	assert.Equal(t, root, job.OutputRoot)
+
+	require.NoError(t, job.BeginValidation())
+	assert.Equal(t, JobStatusValidating, job.Status)
+
+	require.NoError(t, job.Complete(completedAt))
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	require.NotNil(t, job.CompletedAt)
+	assert.Equal(t, completedAt, *job.CompletedAt)
+	assert.True(t, job.Status.IsTerminal())
+}
+
+func TestJob_IllegalTransitions(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  JobStatus
+		apply func(*Job) error
+	}{
+		{"Execute before assignment", JobStatusPending, (*Job).StartExecution},
+		{"Commit before execution", JobStatusAssigned, func(j *Job) error { return j.Commit(common.HexToHash("0x01")) }},
+		{"Validate before commit", JobStatusExecuting, (*Job).BeginValidation},
+		{"Complete without validation", JobStatusCommitted, func(j *Job) error { return j.Complete(time.Now()) }},
+		{"Slash before commit", JobStatusExecuting, (*Job).Slash},
+		{"Reassign", JobStatusAssigned, func(j *Job) error { return j.Assign(common.HexToAddress("0x02")) }},
+		{"Fail completed", JobStatusCompleted, (*Job).Fail},
+		{"Revive failed", JobStatusFailed, (*Job).StartExecution},
+		{"Complete slashed", JobStatusSlashed, func(j *Job) error { return j.Complete(time.Now()) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := Job{ID: "illegal", Status: tt.from}
+			err := tt.apply(&job)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+
+			var te *TransitionError
+			require.ErrorAs(t, err, &te)
+			assert.Equal(t, tt.from, te.From)
+			assert.Equal(t, tt.from, job.Status)
+			assert.Nil(t, job.CompletedAt)
+		})
+	}
+}
+
+func TestJob_FailAndSlash(t *testing.T) {
+	for _, status := range []JobStatus{JobStatusPending, JobStatusAssigned, JobStatusExecuting, JobStatusCommitted, JobStatusValidating} {
+		job := Job{Status: status}
+		assert.NoError(t, job.Fail(), "fail from %s", status)
+		assert.Equal(t, JobStatusFailed, job.Status)
+	}
+
+	for _, status := range []JobStatus{JobStatusCommitted, JobStatusValidating} {
+		job := Job{Status: status}
+		assert.NoError(t, job.Slash(), "slash from %s", status)
+		assert.Equal(t, JobStatusSlashed, job.Status)
+	}
+}
+
+func TestJob_TransitionArguments(t *testing.T) {
+	job := Job{Status: JobStatusPending}
+	assert.ErrorIs(t, job.Assign(common.Address{}), ErrNoWorker)
+	assert.Equal(t, JobStatusPending, job.Status)
+
+	job = Job{Status: JobStatusExecuting}
+	assert.ErrorIs(t, job.Commit(common.Hash{}), ErrNoOutputRoot)
+	assert.Equal(t, JobStatusExecuting, job.Status)
+}
+
+func TestJobStatus_IsTerminal(t *testing.T) {
+	assert.True(t, JobStatusCompleted.IsTerminal())
+	assert.True(t, JobStatusFailed.IsTerminal())
+	assert.True(t, JobStatusSlashed.IsTerminal())
+	assert.False(t, JobStatusPending.IsTerminal())
+	assert.False(t, JobStatusValidating.IsTerminal())
+	assert.False(t, JobStatus("").IsTerminal())
+	assert.False(t, JobStatus("archived").IsTerminal())
+}
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(JobStatusPending, JobStatusAssigned))
+	assert.True(t, CanTransition(JobStatusValidating, JobStatusSlashed))
+	assert.False(t, CanTransition(JobStatusPending, JobStatusCompleted))
+	assert.False(t, CanTransition(JobStatusCompleted, JobStatusPending))
+}
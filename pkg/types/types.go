@@ -20,6 +20,8 @@ type Job struct {
 	SubmittedAt time.Time      `json:"submitted_at"`
 	CompletedAt *time.Time     `json:"completed_at,omitempty"`
 	OutputRoot  common.Hash    `json:"output_root,omitempty"`
+	OutputSize  int            `json:"output_size,omitempty"` // Number of output chunks under OutputRoot
+	PoPCRounds  []PoPCRound    `json:"popc_rounds,omitempty"`
 }
 
 // JobSpecs defines the requirements for a compute job
@@ -53,6 +55,26 @@ const (
 	JobStatusSlashed    JobStatus = "slashed"
 )
 
+// PoPCRound records the outcome of one PoPC challenge round on a job
+type PoPCRound struct {
+	Round      int           `json:"round"` // 1-based
+	Kind       PoPCRoundKind `json:"kind"`
+	Seed       common.Hash   `json:"seed"`
+	Challenged int           `json:"challenged"` // Number of indices challenged
+	Mismatches int           `json:"mismatches"` // Valid proof, incorrect result
+	Missing    int           `json:"missing"`    // No answer or invalid proof
+	Passed     bool          `json:"passed"`
+	CheckedAt  time.Time     `json:"checked_at"`
+}
+
+// PoPCRoundKind distinguishes sampled rounds from full re-execution
+type PoPCRoundKind string
+
+const (
+	PoPCRoundSample PoPCRoundKind = "sample"
+	PoPCRoundFull   PoPCRoundKind = "full"
+)
+
 // Worker represents a compute provider in the network
 type Worker struct {
 	Address      common.Address   `json:"address"`
@@ -77,6 +99,8 @@ type WorkerSpecs struct {
 	Region       string    `json:"region"`
 	ASN          string    `json:"asn"`
 	Organization string    `json:"organization"`
+	Frameworks   []string  `json:"frameworks,omitempty"` // e.g. PyTorch, TensorFlow
+	Tags         []string  `json:"tags,omitempty"`       // Capabilities matched against JobSpecs.Tags
 }
 
 // GPUSpec defines GPU specifications